@@ -8,11 +8,23 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+// Хранилища данных: postgres или память процесса (для тестов и локальных демо, данные не сохраняются)
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
 type (
 	Config struct {
+		Storage  string `yaml:"storage" env:"STORAGE" env-default:"postgres"`
 		HTTP     `yaml:"http"`
 		Database `yaml:"database"`
 		Log      `yaml:"log"`
+		Auth     `yaml:"auth"`
+		Search   `yaml:"search"`
+		Outbox   `yaml:"outbox"`
+		Webhook  `yaml:"webhook"`
+		Metrics  `yaml:"metrics"`
 	}
 
 	HTTP struct {
@@ -23,13 +35,48 @@ type (
 	}
 
 	Database struct {
-		Conn        string `env-required:"true" env:"POSTGRES_CONN"`
+		Conn        string `env:"POSTGRES_CONN"`
 		MaxPoolSize int    `env-required:"true" yaml:"max_pool_size" env:"MAX_POOL_SIZE"`
 	}
 
 	Log struct {
 		Level string `env-required:"true" yaml:"level" env:"LOG_LEVEL"`
 	}
+
+	// Auth DevTokens включает POST /api/auth/token, выдающий токен по имени пользователя без проверки;
+	// только для локальной разработки
+	Auth struct {
+		SigningKey string        `env-required:"true" env:"AUTH_SIGNING_KEY"`
+		TokenTTL   time.Duration `env-required:"true" yaml:"token_ttl" env:"AUTH_TOKEN_TTL"`
+		DevTokens  bool          `yaml:"dev_tokens" env:"AUTH_DEV_TOKENS" env-default:"false"`
+	}
+
+	// Search конфигурация стемминга полнотекстового поиска: russian или english
+	Search struct {
+		Language string `yaml:"language" env:"SEARCH_LANGUAGE" env-default:"russian"`
+	}
+
+	// Outbox конфигурация доставки доменных событий
+	Outbox struct {
+		Interval    time.Duration `yaml:"interval" env:"OUTBOX_INTERVAL" env-default:"1s"`
+		BatchSize   int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+		MaxAttempts int           `yaml:"max_attempts" env:"OUTBOX_MAX_ATTEMPTS" env-default:"10"`
+	}
+
+	// Webhook конфигурация доставки вебхуков: задержка n-го повтора backoff_base * 2^(n-1), не больше backoff_max
+	Webhook struct {
+		Interval    time.Duration `yaml:"interval" env:"WEBHOOK_INTERVAL" env-default:"1s"`
+		BatchSize   int           `yaml:"batch_size" env:"WEBHOOK_BATCH_SIZE" env-default:"20"`
+		Timeout     time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+		MaxAttempts int           `yaml:"max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+		BackoffBase time.Duration `yaml:"backoff_base" env:"WEBHOOK_BACKOFF_BASE" env-default:"10s"`
+		BackoffMax  time.Duration `yaml:"backoff_max" env:"WEBHOOK_BACKOFF_MAX" env-default:"1h"`
+	}
+
+	// Metrics порт отдельного HTTP-сервера с GET /metrics, на порту API метрик нет; пустой порт отключает метрики
+	Metrics struct {
+		Port string `yaml:"port" env:"METRICS_PORT"`
+	}
 )
 
 func NewConfig(configPath string) (*Config, error) {
@@ -45,5 +92,19 @@ func NewConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("error updating env: %w", err)
 	}
 
+	switch cfg.Storage {
+	case StoragePostgres:
+		if cfg.Database.Conn == "" {
+			return nil, fmt.Errorf("POSTGRES_CONN is required for %s storage", StoragePostgres)
+		}
+	case StorageMemory:
+	default:
+		return nil, fmt.Errorf("unsupported storage: %s", cfg.Storage)
+	}
+
+	if cfg.Search.Language != "russian" && cfg.Search.Language != "english" {
+		return nil, fmt.Errorf("unsupported search language: %s", cfg.Search.Language)
+	}
+
 	return cfg, nil
 }
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,10 +11,15 @@ import (
 	"github.com/go-chi/chi/v5"
 	"tender-service/config"
 	v1 "tender-service/internal/handler/http/v1"
+	"tender-service/internal/metrics"
+	"tender-service/internal/outbox"
 	"tender-service/internal/repo"
+	"tender-service/internal/repo/memory"
 	"tender-service/internal/service"
+	"tender-service/internal/webhook"
 	"tender-service/pkg/httpserver"
 	"tender-service/pkg/postgres"
+	"tender-service/pkg/token"
 )
 
 func Run(configPath string) {
@@ -22,23 +28,50 @@ func Run(configPath string) {
 	// config
 	cfg, err := config.NewConfig(configPath)
 	if err != nil {
-		fmt.Println(err.Error())
+		fmt.Println(fmt.Errorf("app - Run - config.NewConfig: %w", err).Error())
+		return
 	}
-	fmt.Println(cfg)
 
 	//logger
 	log := setLogger(cfg.Level)
 	log.Info("Init logger")
 
-	//postgres
-	database, err := postgres.New(ctx, cfg.Conn, postgres.MaxPoolSize(cfg.MaxPoolSize))
+	//metrics
+	m := metrics.New()
+
+	//repositories
+	repos, err := newRepositories(ctx, cfg, m)
 	if err != nil {
-		fmt.Println(err.Error())
+		log.Error(fmt.Errorf("app - Run - newRepositories: %w", err).Error())
+		return
+	}
+	log.Info("Storage: " + cfg.Storage)
+	dependencies := service.ServicesDependencies{
+		Repos: repos, SearchLanguage: cfg.Search.Language, Metrics: m,
 	}
 
-	//repositories
-	repos := repo.NewRepositories(database)
-	dependencies := service.ServicesDependencies{Repos: repos}
+	//outbox
+	dispatcher := outbox.NewDispatcher(
+		repos.Outbox,
+		outbox.NewMultiPublisher(outbox.NewLogPublisher(log), webhook.NewPublisher(repos.Webhook)),
+		log,
+		outbox.Interval(cfg.Outbox.Interval),
+		outbox.BatchSize(cfg.Outbox.BatchSize),
+		outbox.MaxAttempts(cfg.Outbox.MaxAttempts),
+	)
+	go dispatcher.Run(ctx)
+
+	//webhooks
+	webhookWorker := webhook.NewWorker(
+		repos.Webhook,
+		webhook.NewSender(&http.Client{Timeout: cfg.Webhook.Timeout}),
+		log,
+		webhook.Interval(cfg.Webhook.Interval),
+		webhook.BatchSize(cfg.Webhook.BatchSize),
+		webhook.MaxAttempts(cfg.Webhook.MaxAttempts),
+		webhook.Backoff(cfg.Webhook.BackoffBase, cfg.Webhook.BackoffMax),
+	)
+	go webhookWorker.Run(ctx)
 
 	//services
 	services := service.NewServices(dependencies)
@@ -46,13 +79,26 @@ func Run(configPath string) {
 	//handlers
 	log.Info("Initializing handlers and routes...")
 
+	tokens := token.New(cfg.SigningKey, cfg.TokenTTL)
+
 	router := chi.NewRouter()
-	v1.NewRouter(ctx, log, router, services)
+	v1.NewRouter(ctx, log, router, services, tokens, m, cfg.DevTokens)
 	// HTTP server
 	log.Info("Starting http server...")
-	log.Debug(fmt.Sprintf("Server port: %s", cfg.Port))
+	log.Debug(fmt.Sprintf("Server port: %s", cfg.HTTP.Port))
 	httpServer := httpserver.New(router, httpserver.Port(cfg.HTTP.Port))
 
+	// метрики на отдельном порту, который не публикуется наружу вместе с API
+	var metricsServer *httpserver.Server
+	var metricsNotify <-chan error
+	if cfg.Metrics.Port != "" {
+		log.Info(fmt.Sprintf("Starting metrics server on port %s...", cfg.Metrics.Port))
+		metricsRouter := chi.NewRouter()
+		metricsRouter.Handle("/metrics", m.Handler())
+		metricsServer = httpserver.New(metricsRouter, httpserver.Port(cfg.Metrics.Port))
+		metricsNotify = metricsServer.Notify()
+	}
+
 	// Waiting signal
 	log.Info("Configuring graceful shutdown...")
 	interrupt := make(chan os.Signal, 1)
@@ -63,12 +109,36 @@ func Run(configPath string) {
 		log.Info("app - Run - signal: " + s.String())
 	case err = <-httpServer.Notify():
 		log.Error(fmt.Errorf("app - Run - httpServer.Notify: %w", err).Error())
+	case err = <-metricsNotify:
+		log.Error(fmt.Errorf("app - Run - metricsServer.Notify: %w", err).Error())
 	}
 
 	// Graceful shutdown
 	log.Info("Shutting down...")
+	cancel()
 	err = httpServer.Shutdown()
 	if err != nil {
 		log.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err).Error())
 	}
+	if metricsServer != nil {
+		if err = metricsServer.Shutdown(); err != nil {
+			log.Error(fmt.Errorf("app - Run - metricsServer.Shutdown: %w", err).Error())
+		}
+	}
+}
+
+// newRepositories репозитории выбранного в конфигурации хранилища; для postgres также метрики пула
+func newRepositories(ctx context.Context, cfg *config.Config, m *metrics.Metrics) (*repo.Repositories, error) {
+	if cfg.Storage == config.StorageMemory {
+		return memory.NewRepositories(), nil
+	}
+
+	migrateUp(cfg.Conn)
+
+	database, err := postgres.New(ctx, cfg.Conn, postgres.MaxPoolSize(cfg.MaxPoolSize))
+	if err != nil {
+		return nil, fmt.Errorf("app - newRepositories - postgres.New: %w", err)
+	}
+	m.RegisterPool(database.Cluster)
+	return repo.NewRepositories(database), nil
 }
@@ -2,9 +2,7 @@ package app
 
 import (
 	"errors"
-	"fmt"
 	"log"
-	"os"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -17,12 +15,9 @@ const (
 	defaultTimeout  = time.Second
 )
 
-func init() {
+// migrateUp применяет миграции к базе conn; для хранилища в памяти не вызывается
+func migrateUp(conn string) {
 	log.Printf("Migrate: start")
-	conn, ok := os.LookupEnv("POSTGRES_CONN")
-	if !ok {
-		fmt.Printf("%s not set\n", "POSTGRES_CONN")
-	}
 	conn += "?sslmode=disable"
 
 	attempts := defaultAttempts
@@ -0,0 +1,63 @@
+// Package audit записывает в журнал изменения тендеров и предложений.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+	mw "tender-service/pkg/middleware"
+)
+
+// Entry что изменено. Версия 0 означает, что версии нет (например, старой версии при создании)
+type Entry struct {
+	Action     string
+	EntityType string
+	EntityId   string
+	OldVersion int
+	NewVersion int
+}
+
+type Recorder struct {
+	audit repo.Audit
+}
+
+func New(audit repo.Audit) *Recorder {
+	return &Recorder{audit: audit}
+}
+
+// Record пишет запись; автор берется из контекста middleware Auth, request ID - из middleware.RequestID.
+// Изменение к этому моменту уже сохранено, поэтому ошибка записи только логируется.
+func (r *Recorder) Record(ctx context.Context, log *slog.Logger, e Entry) {
+	user, _ := mw.UserFromContext(ctx)
+
+	err := r.audit.Create(
+		context.WithoutCancel(ctx), entity.AuditEntry{
+			ActorId:       user.Id,
+			ActorUsername: user.Username,
+			Action:        e.Action,
+			EntityType:    e.EntityType,
+			EntityId:      e.EntityId,
+			OldVersion:    version(e.OldVersion),
+			NewVersion:    version(e.NewVersion),
+			RequestId:     middleware.GetReqID(ctx),
+		},
+	)
+	if err != nil {
+		log.Error(
+			fmt.Sprintf("audit - Recorder - Record: %v", err),
+			slog.String("action", e.Action),
+			slog.String("entityId", e.EntityId),
+		)
+	}
+}
+
+func version(v int) *int {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
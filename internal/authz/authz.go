@@ -0,0 +1,160 @@
+// Package authz собирает в одном месте правила доступа к тендерам и предложениям.
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+)
+
+const (
+	tenderStatusPublished = "Published"
+
+	bidStatusCreated  = "Created"
+	bidStatusCanceled = "Canceled"
+)
+
+var ErrForbidden = errors.New("forbidden")
+
+// OrgResponsibles часть repo.OrgResponsible, нужная для проверок; GetByIds не находит ответственных удаленной организации
+type OrgResponsibles interface {
+	GetByIds(ctx context.Context, input entity.OrgResponsible) (entity.OrgResponsible, error)
+}
+
+// Tenders часть repo.Tender, нужная для проверок
+type Tenders interface {
+	GetById(ctx context.Context, id string) (entity.Tender, error)
+}
+
+type Policy struct {
+	orgResponsibles OrgResponsibles
+	tenders         Tenders
+}
+
+func New(orgResponsibles OrgResponsibles, tenders Tenders) *Policy {
+	return &Policy{orgResponsibles: orgResponsibles, tenders: tenders}
+}
+
+// IsOrgResponsible является ли пользователь ответственным за организацию;
+// за удаленную организацию ответственных нет, деактивированный пользователь ни за что не отвечает
+func (p *Policy) IsOrgResponsible(ctx context.Context, user entity.User, organizationId string) (bool, error) {
+	if !user.IsActive() {
+		return false, nil
+	}
+	_, err := p.orgResponsibles.GetByIds(
+		ctx, entity.OrgResponsible{
+			OrganizationId: organizationId,
+			UserId:         user.Id,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("authz - IsOrgResponsible - GetByIds: %w", err)
+	}
+	return true, nil
+}
+
+func (p *Policy) requireOrgResponsible(ctx context.Context, user entity.User, organizationId string) error {
+	ok, err := p.IsOrgResponsible(ctx, user, organizationId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// CanManageOrganization данные и состав ответственных организации меняют ответственные за нее
+func (p *Policy) CanManageOrganization(ctx context.Context, user entity.User, organizationId string) error {
+	return p.requireOrgResponsible(ctx, user, organizationId)
+}
+
+// CanCreateTender тендер создает ответственный за организацию
+func (p *Policy) CanCreateTender(ctx context.Context, user entity.User, organizationId string) error {
+	return p.requireOrgResponsible(ctx, user, organizationId)
+}
+
+// CanViewTender опубликованный тендер виден всем, остальные - только ответственным за организацию
+func (p *Policy) CanViewTender(ctx context.Context, user entity.User, t entity.Tender) error {
+	if t.Status == tenderStatusPublished {
+		return nil
+	}
+	return p.requireOrgResponsible(ctx, user, t.OrganizationId)
+}
+
+// CanEditTender статус, параметры и версии тендера меняют ответственные за организацию
+func (p *Policy) CanEditTender(ctx context.Context, user entity.User, t entity.Tender) error {
+	return p.requireOrgResponsible(ctx, user, t.OrganizationId)
+}
+
+// CanViewTenderHistory версии и отзывы по тендеру видят ответственные за организацию
+func (p *Policy) CanViewTenderHistory(ctx context.Context, user entity.User, t entity.Tender) error {
+	return p.requireOrgResponsible(ctx, user, t.OrganizationId)
+}
+
+// IsBidAuthor является ли пользователь автором предложения: лично
+// или как ответственный за организацию-автора; деактивированный пользователь прав автора не имеет
+func (p *Policy) IsBidAuthor(ctx context.Context, user entity.User, b entity.Bid) (bool, error) {
+	if !user.IsActive() {
+		return false, nil
+	}
+	switch b.AuthorType {
+	case entity.BidAuthorTypeUser:
+		return b.AuthorId == user.Id, nil
+	case entity.BidAuthorTypeOrganization:
+		return p.IsOrgResponsible(ctx, user, b.AuthorId)
+	default:
+		return false, nil
+	}
+}
+
+// CanCreateBid предложение от имени пользователя создает только сам пользователь,
+// от имени организации - ответственный за нее
+func (p *Policy) CanCreateBid(ctx context.Context, user entity.User, b entity.Bid) error {
+	ok, err := p.IsBidAuthor(ctx, user, b)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// CanViewBid предложение в любом статусе видит автор (ответственные за организацию-автора),
+// ответственные за организацию тендера - все, кроме черновиков и отмененных, как в списках предложений
+func (p *Policy) CanViewBid(ctx context.Context, user entity.User, b entity.Bid) error {
+	ok, err := p.IsBidAuthor(ctx, user, b)
+	if err != nil || ok {
+		return err
+	}
+	if b.Status == bidStatusCreated || b.Status == bidStatusCanceled {
+		return ErrForbidden
+	}
+	return p.requireTenderResponsible(ctx, user, b)
+}
+
+// CanEditBid предложение редактирует и откатывает автор, для предложения организации -
+// любой ответственный за нее
+func (p *Policy) CanEditBid(ctx context.Context, user entity.User, b entity.Bid) error {
+	return p.CanCreateBid(ctx, user, b)
+}
+
+// CanDecideBid решение и отзыв по предложению оставляют ответственные за организацию тендера
+func (p *Policy) CanDecideBid(ctx context.Context, user entity.User, b entity.Bid) error {
+	return p.requireTenderResponsible(ctx, user, b)
+}
+
+func (p *Policy) requireTenderResponsible(ctx context.Context, user entity.User, b entity.Bid) error {
+	t, err := p.tenders.GetById(ctx, b.TenderId)
+	if err != nil {
+		return fmt.Errorf("authz - requireTenderResponsible - GetById: %w", err)
+	}
+	return p.requireOrgResponsible(ctx, user, t.OrganizationId)
+}
@@ -0,0 +1,251 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+)
+
+const (
+	tenderOrg = "tender-org"
+	authorOrg = "author-org"
+	tenderId  = "tender"
+)
+
+var deactivatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+var (
+	responsible       = entity.User{Id: "responsible"}
+	authorOrgResp     = entity.User{Id: "author-org-responsible"}
+	author            = entity.User{Id: "author"}
+	stranger          = entity.User{Id: "stranger"}
+	deactivatedResp   = entity.User{Id: "responsible", DeactivatedAt: &deactivatedAt}
+	deactivatedAuthor = entity.User{Id: "author", DeactivatedAt: &deactivatedAt}
+)
+
+// fakeResponsibles ответственные по организациям
+type fakeResponsibles map[string][]string
+
+func (f fakeResponsibles) GetByIds(_ context.Context, input entity.OrgResponsible) (entity.OrgResponsible, error) {
+	for _, userId := range f[input.OrganizationId] {
+		if userId == input.UserId {
+			return input, nil
+		}
+	}
+	return entity.OrgResponsible{}, repoerrs.ErrNotFound
+}
+
+type fakeTenders map[string]entity.Tender
+
+func (f fakeTenders) GetById(_ context.Context, id string) (entity.Tender, error) {
+	t, ok := f[id]
+	if !ok {
+		return entity.Tender{}, repoerrs.ErrNotFound
+	}
+	return t, nil
+}
+
+func newPolicy() *Policy {
+	return New(
+		fakeResponsibles{tenderOrg: {responsible.Id}, authorOrg: {authorOrgResp.Id}},
+		fakeTenders{tenderId: {Id: tenderId, OrganizationId: tenderOrg, Status: tenderStatusPublished}},
+	)
+}
+
+var (
+	userBid = entity.Bid{TenderId: tenderId, AuthorType: entity.BidAuthorTypeUser, AuthorId: author.Id}
+	orgBid  = entity.Bid{TenderId: tenderId, AuthorType: entity.BidAuthorTypeOrganization, AuthorId: authorOrg}
+)
+
+type testCase struct {
+	name  string
+	user  entity.User
+	allow bool
+}
+
+func run(t *testing.T, tests []testCase, check func(user entity.User) error) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check(tt.user)
+			if tt.allow && err != nil {
+				t.Fatalf("want allowed, got %v", err)
+			}
+			if !tt.allow && !errors.Is(err, ErrForbidden) {
+				t.Fatalf("want ErrForbidden, got %v", err)
+			}
+		})
+	}
+}
+
+// orgTests правила "только ответственные за организацию тендера"
+var orgTests = []testCase{
+	{name: "responsible", user: responsible, allow: true},
+	{name: "responsible of another organization", user: authorOrgResp, allow: false},
+	{name: "not responsible", user: stranger, allow: false},
+	{name: "deactivated responsible", user: deactivatedResp, allow: false},
+}
+
+func TestCanManageOrganization(t *testing.T) {
+	p := newPolicy()
+	run(t, orgTests, func(user entity.User) error {
+		return p.CanManageOrganization(context.Background(), user, tenderOrg)
+	})
+}
+
+func TestCanCreateTender(t *testing.T) {
+	p := newPolicy()
+	run(t, orgTests, func(user entity.User) error {
+		return p.CanCreateTender(context.Background(), user, tenderOrg)
+	})
+}
+
+func TestCanViewTender(t *testing.T) {
+	p := newPolicy()
+	for _, status := range []string{"Created", tenderStatusPublished, "Closed"} {
+		tender := entity.Tender{Id: tenderId, OrganizationId: tenderOrg, Status: status}
+		published := status == tenderStatusPublished
+
+		t.Run(status, func(t *testing.T) {
+			// опубликованный тендер виден всем, остальные - только ответственным
+			run(t, []testCase{
+				{name: "responsible", user: responsible, allow: true},
+				{name: "not responsible", user: stranger, allow: published},
+				{name: "deactivated responsible", user: deactivatedResp, allow: published},
+			}, func(user entity.User) error {
+				return p.CanViewTender(context.Background(), user, tender)
+			})
+		})
+	}
+}
+
+func TestCanEditTender(t *testing.T) {
+	p := newPolicy()
+	for _, status := range []string{tenderStatusPublished, "Closed"} {
+		tender := entity.Tender{Id: tenderId, OrganizationId: tenderOrg, Status: status}
+		t.Run(status, func(t *testing.T) {
+			run(t, orgTests, func(user entity.User) error {
+				return p.CanEditTender(context.Background(), user, tender)
+			})
+		})
+	}
+}
+
+func TestCanViewTenderHistory(t *testing.T) {
+	p := newPolicy()
+	for _, status := range []string{tenderStatusPublished, "Closed"} {
+		tender := entity.Tender{Id: tenderId, OrganizationId: tenderOrg, Status: status}
+		t.Run(status, func(t *testing.T) {
+			run(t, orgTests, func(user entity.User) error {
+				return p.CanViewTenderHistory(context.Background(), user, tender)
+			})
+		})
+	}
+}
+
+func TestCanCreateBid(t *testing.T) {
+	p := newPolicy()
+	t.Run("user author", func(t *testing.T) {
+		run(t, []testCase{
+			{name: "author", user: author, allow: true},
+			{name: "another user", user: stranger, allow: false},
+			{name: "tender responsible", user: responsible, allow: false},
+			{name: "deactivated author", user: deactivatedAuthor, allow: false},
+		}, func(user entity.User) error {
+			return p.CanCreateBid(context.Background(), user, userBid)
+		})
+	})
+	t.Run("organization author", func(t *testing.T) {
+		run(t, []testCase{
+			{name: "author organization responsible", user: authorOrgResp, allow: true},
+			{name: "another user", user: stranger, allow: false},
+			{name: "tender responsible", user: responsible, allow: false},
+		}, func(user entity.User) error {
+			return p.CanCreateBid(context.Background(), user, orgBid)
+		})
+	})
+	t.Run("unknown author type", func(t *testing.T) {
+		bid := entity.Bid{TenderId: tenderId, AuthorType: "Unknown", AuthorId: author.Id}
+		run(t, []testCase{{name: "author", user: author, allow: false}}, func(user entity.User) error {
+			return p.CanCreateBid(context.Background(), user, bid)
+		})
+	})
+}
+
+func TestCanEditBid(t *testing.T) {
+	p := newPolicy()
+	t.Run("user author", func(t *testing.T) {
+		run(t, []testCase{
+			{name: "author", user: author, allow: true},
+			{name: "tender responsible", user: responsible, allow: false},
+			{name: "deactivated author", user: deactivatedAuthor, allow: false},
+		}, func(user entity.User) error {
+			return p.CanEditBid(context.Background(), user, userBid)
+		})
+	})
+	t.Run("organization author", func(t *testing.T) {
+		run(t, []testCase{
+			{name: "author organization responsible", user: authorOrgResp, allow: true},
+			{name: "tender responsible", user: responsible, allow: false},
+		}, func(user entity.User) error {
+			return p.CanEditBid(context.Background(), user, orgBid)
+		})
+	})
+}
+
+func TestCanViewBid(t *testing.T) {
+	p := newPolicy()
+	for _, status := range []string{"Created", "Published", "Canceled", "Approved", "Rejected"} {
+		// черновики и отмененные предложения ответственным за тендер не видны
+		visible := status != "Created" && status != "Canceled"
+		userBid, orgBid := userBid, orgBid
+		userBid.Status, orgBid.Status = status, status
+
+		t.Run(status, func(t *testing.T) {
+			t.Run("user author", func(t *testing.T) {
+				run(t, []testCase{
+					{name: "author", user: author, allow: true},
+					{name: "tender responsible", user: responsible, allow: visible},
+					{name: "another user", user: stranger, allow: false},
+					{name: "deactivated author", user: deactivatedAuthor, allow: false},
+					{name: "deactivated tender responsible", user: deactivatedResp, allow: false},
+				}, func(user entity.User) error {
+					return p.CanViewBid(context.Background(), user, userBid)
+				})
+			})
+			t.Run("organization author", func(t *testing.T) {
+				run(t, []testCase{
+					{name: "author organization responsible", user: authorOrgResp, allow: true},
+					{name: "tender responsible", user: responsible, allow: visible},
+					{name: "another user", user: stranger, allow: false},
+				}, func(user entity.User) error {
+					return p.CanViewBid(context.Background(), user, orgBid)
+				})
+			})
+		})
+	}
+}
+
+func TestCanDecideBid(t *testing.T) {
+	p := newPolicy()
+	run(t, []testCase{
+		{name: "tender responsible", user: responsible, allow: true},
+		{name: "user author", user: author, allow: false},
+		{name: "author organization responsible", user: authorOrgResp, allow: false},
+		{name: "deactivated tender responsible", user: deactivatedResp, allow: false},
+	}, func(user entity.User) error {
+		return p.CanDecideBid(context.Background(), user, orgBid)
+	})
+
+	t.Run("missing tender", func(t *testing.T) {
+		bid := entity.Bid{TenderId: "missing", AuthorType: entity.BidAuthorTypeUser, AuthorId: author.Id}
+		err := p.CanDecideBid(context.Background(), responsible, bid)
+		if err == nil || errors.Is(err, ErrForbidden) {
+			t.Fatalf("want repository error, got %v", err)
+		}
+	})
+}
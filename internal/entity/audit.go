@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+const (
+	AuditActionCreate   = "create"
+	AuditActionEdit     = "edit"
+	AuditActionStatus   = "status"
+	AuditActionDecision = "decision"
+	AuditActionRollback = "rollback"
+
+	AuditEntityTender = "tender"
+	AuditEntityBid    = "bid"
+)
+
+// AuditEntry запись журнала изменений тендеров и предложений
+type AuditEntry struct {
+	Id             string    `db:"id"`
+	ActorId        string    `db:"actor_id"`
+	ActorUsername  string    `db:"actor_username"`
+	Action         string    `db:"action"`
+	EntityType     string    `db:"entity_type"`
+	EntityId       string    `db:"entity_id"`
+	OrganizationId *string   `db:"organization_id"`
+	OldVersion     *int      `db:"old_version"`
+	NewVersion     *int      `db:"new_version"`
+	RequestId      string    `db:"request_id"`
+	CreatedAt      time.Time `db:"created_at"`
+}
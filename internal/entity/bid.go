@@ -2,6 +2,12 @@ package entity
 
 import "time"
 
+// Тип автора предложения: пользователь или организация
+const (
+	BidAuthorTypeUser         = "User"
+	BidAuthorTypeOrganization = "Organization"
+)
+
 type Bid struct {
 	Id          string    `db:"id"`
 	Name        string    `db:"name"`
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+type BidDecision struct {
+	Id        string    `db:"id"`
+	BidId     string    `db:"bid_id"`
+	UserId    string    `db:"user_id"`
+	Decision  string    `db:"decision"`
+	CreatedAt time.Time `db:"created_at"`
+}
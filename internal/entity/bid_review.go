@@ -3,8 +3,10 @@ package entity
 import "time"
 
 type BidReview struct {
-	Id          int       `db:"id"`
-	Description string    `db:"description"`
-	BidId       string    `db:"bid_id"`
-	CreatedAt   time.Time `db:"created_at"`
+	Id             string    `db:"id"`
+	Description    string    `db:"description"`
+	BidId          string    `db:"bid_id"`
+	CreatedAt      time.Time `db:"created_at"`
+	UserId         string    `db:"user_id"`
+	OrganizationId string    `db:"organization_id"`
 }
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType тип доменного события
+type EventType string
+
+const (
+	EventTenderPublished EventType = "tender.published"
+	EventTenderClosed    EventType = "tender.closed"
+	EventBidCreated      EventType = "bid.created"
+	EventBidApproved     EventType = "bid.approved"
+	EventBidRejected     EventType = "bid.rejected"
+)
+
+// Event доменное событие из outbox. Payload - JSON одной из структур *Payload ниже
+type Event struct {
+	Id          string          `db:"id"`
+	Type        EventType       `db:"event_type"`
+	AggregateId string          `db:"aggregate_id"`
+	Payload     json.RawMessage `db:"payload"`
+	CreatedAt   time.Time       `db:"created_at"`
+	Attempts    int             `db:"attempts"`
+}
+
+// TenderStatusPayload тендер опубликован или закрыт
+type TenderStatusPayload struct {
+	TenderId       string `json:"tenderId"`
+	OrganizationId string `json:"organizationId"`
+	Status         string `json:"status"`
+}
+
+// BidCreatedPayload создано предложение
+type BidCreatedPayload struct {
+	BidId      string `json:"bidId"`
+	TenderId   string `json:"tenderId"`
+	AuthorType string `json:"authorType"`
+	AuthorId   string `json:"authorId"`
+}
+
+// BidDecidedPayload предложение одобрено или отклонено
+type BidDecidedPayload struct {
+	BidId    string `json:"bidId"`
+	TenderId string `json:"tenderId"`
+	Decision string `json:"decision"`
+}
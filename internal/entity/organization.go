@@ -5,10 +5,11 @@ import (
 )
 
 type Organization struct {
-	Id               string    `db:"id"`
-	Name             string    `db:"name"`
-	Description      string    `db:"description"`
-	OrganizationType string    `db:"organization_type"`
-	CreatedAt        time.Time `db:"created_at"`
-	UpdatedAt        time.Time `db:"updated_at"`
+	Id               string     `db:"id"`
+	Name             string     `db:"name"`
+	Description      string     `db:"description"`
+	OrganizationType string     `db:"organization_type"`
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+	DeletedAt        *time.Time `db:"deleted_at"`
 }
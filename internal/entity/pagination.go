@@ -0,0 +1,34 @@
+package entity
+
+// Cursor ключ keyset-пагинации: значения колонок сортировки и id последней отданной строки.
+// Sort - сортировка, для которой выдан курсор
+type Cursor struct {
+	Keys []string `json:"k"`
+	Id   string   `json:"i"`
+	Sort string   `json:"s,omitempty"`
+}
+
+// SortField поле сортировки списка
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Pagination параметры страницы. Если задан After, Offset игнорируется.
+// Sort пуст - сортировка по умолчанию (по названию).
+// WithTotal - посчитать общее число строк, подходящих под фильтр
+type Pagination struct {
+	Limit     int
+	Offset    int
+	After     *Cursor
+	Sort      []SortField
+	WithTotal bool
+}
+
+// Page страница списка; Next пуст, если дальше строк нет.
+// Total заполнен, только если запрошен WithTotal и его удалось определить
+type Page[T any] struct {
+	Items []T
+	Next  *Cursor
+	Total *int
+}
@@ -2,6 +2,16 @@ package entity
 
 import "time"
 
+// Поля, по которым можно сортировать список тендеров
+const (
+	TenderSortName      = "name"
+	TenderSortCreatedAt = "createdAt"
+	TenderSortVersion   = "version"
+)
+
+// TenderSortFields допустимые поля сортировки списка тендеров
+var TenderSortFields = []string{TenderSortName, TenderSortCreatedAt, TenderSortVersion}
+
 type Tender struct {
 	Id              string    `db:"id"`
 	Name            string    `db:"name"`
@@ -13,3 +23,13 @@ type Tender struct {
 	CreatedAt       time.Time `db:"created_at"`
 	CreatorUsername string    `db:"creator_username"`
 }
+
+// TenderFilter фильтры списка тендеров; пустые поля не ограничивают выборку
+type TenderFilter struct {
+	ServiceType     []string
+	Status          []string
+	OrganizationId  string
+	CreatorUsername string
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+}
@@ -3,10 +3,16 @@ package entity
 import "time"
 
 type User struct {
-	Id        string    `db:"id"`
-	Username  string    `db:"username"`
-	FirstName string    `db:"first_name"`
-	LastName  string    `db:"last_name"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	Id            string     `db:"id"`
+	Username      string     `db:"username"`
+	FirstName     string     `db:"first_name"`
+	LastName      string     `db:"last_name"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+	DeactivatedAt *time.Time `db:"deactivated_at"`
+}
+
+// IsActive пользователь не деактивирован
+func (u User) IsActive() bool {
+	return u.DeactivatedAt == nil
 }
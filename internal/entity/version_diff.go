@@ -0,0 +1,7 @@
+package entity
+
+type FieldDiff struct {
+	Field string `db:"field"`
+	From  string `db:"from"`
+	To    string `db:"to"`
+}
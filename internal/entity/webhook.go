@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	WebhookDeliveryPending   = "Pending"
+	WebhookDeliveryDelivered = "Delivered"
+	WebhookDeliveryFailed    = "Failed"
+)
+
+// Webhook подписка организации на доменные события
+type Webhook struct {
+	Id             string    `db:"id"`
+	OrganizationId string    `db:"organization_id"`
+	Url            string    `db:"url"`
+	Secret         string    `db:"secret"`
+	EventTypes     []string  `db:"event_types"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// WebhookDelivery попытки доставки одного события по одной подписке
+type WebhookDelivery struct {
+	Id            string          `db:"id"`
+	WebhookId     string          `db:"webhook_id"`
+	EventId       string          `db:"event_id"`
+	EventType     string          `db:"event_type"`
+	Payload       json.RawMessage `db:"payload"`
+	Status        string          `db:"status"`
+	Attempts      int             `db:"attempts"`
+	NextAttemptAt time.Time       `db:"next_attempt_at"`
+	ResponseCode  *int            `db:"response_code"`
+	LastError     *string         `db:"last_error"`
+	CreatedAt     time.Time       `db:"created_at"`
+	DeliveredAt   *time.Time      `db:"delivered_at"`
+
+	// Url и Secret подписки, заполняются при выборке доставок на отправку
+	Url    string `db:"-"`
+	Secret string `db:"-"`
+}
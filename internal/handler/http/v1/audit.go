@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"tender-service/internal/service"
+)
+
+const (
+	auditString = "/audit"
+)
+
+type auditRoutes struct {
+	auditService service.Audit
+}
+
+func newAuditRoutes(ctx context.Context, log *slog.Logger, route chi.Router, auditService service.Audit) {
+	a := auditRoutes{auditService: auditService}
+	route.Route(
+		auditString, func(r chi.Router) {
+			r.Get("/", a.get(ctx, log))
+		},
+	)
+}
+
+type inputAuditGet struct {
+	EntityId string `validate:"required,uuid"`
+	Limit    int    `validate:"omitempty,number,gte=0,lte=50"`
+	Offset   int    `validate:"omitempty,number,gte=0"`
+}
+
+type outputAuditEntry struct {
+	Id            string    `json:"id"`
+	ActorId       string    `json:"actorId"`
+	ActorUsername string    `json:"actorUsername"`
+	Action        string    `json:"action"`
+	EntityType    string    `json:"entityType"`
+	EntityId      string    `json:"entityId"`
+	OldVersion    *int      `json:"oldVersion,omitempty"`
+	NewVersion    *int      `json:"newVersion,omitempty"`
+	RequestId     string    `json:"requestId"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// get журнал изменений тендера или предложения (?entityId=), новые записи первыми.
+// Видны только записи по тендерам организаций, за которые отвечает пользователь
+func (a *auditRoutes) get(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			limit  int
+			offset int
+			err    error
+		)
+		if l := r.URL.Query().Get("limit"); len(l) != 0 {
+			if limit, err = strconv.Atoi(l); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+		if off := r.URL.Query().Get("offset"); len(off) != 0 {
+			if offset, err = strconv.Atoi(off); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+
+		input := inputAuditGet{
+			EntityId: r.URL.Query().Get("entityId"),
+			Limit:    limit,
+			Offset:   offset,
+		}
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done := authenticatedUser(w, r, log, "")
+		if done {
+			return
+		}
+
+		result, err := a.auditService.GetByEntity(
+			ctx, log, service.AuditGetByEntityInput{
+				EntityId: input.EntityId,
+				UserId:   user.Id,
+				Limit:    input.Limit,
+				Offset:   input.Offset,
+			},
+		)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := make([]outputAuditEntry, 0, len(result))
+		for _, v := range result {
+			output = append(
+				output, outputAuditEntry{
+					Id:            v.Id,
+					ActorId:       v.ActorId,
+					ActorUsername: v.ActorUsername,
+					Action:        v.Action,
+					EntityType:    v.EntityType,
+					EntityId:      v.EntityId,
+					OldVersion:    v.OldVersion,
+					NewVersion:    v.NewVersion,
+					RequestId:     v.RequestId,
+					CreatedAt:     v.CreatedAt,
+				},
+			)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
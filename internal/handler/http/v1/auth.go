@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"tender-service/internal/authz"
+	"tender-service/internal/entity"
+	"tender-service/internal/service"
+	mw "tender-service/pkg/middleware"
+	"tender-service/pkg/token"
+)
+
+const (
+	authPath = "/auth"
+)
+
+type authRoutes struct {
+	userService service.User
+	tokens      *token.Manager
+}
+
+func newAuthRoutes(
+	ctx context.Context, log *slog.Logger, route chi.Router, userService service.User, tokens *token.Manager,
+) {
+	a := authRoutes{userService: userService, tokens: tokens}
+	route.Route(
+		authPath, func(r chi.Router) {
+			r.Post("/token", a.token(ctx, log))
+		},
+	)
+}
+
+// authenticatedUser пользователь, определенный middleware по токену.
+// Деактивированный пользователь получает 403. Если в запросе передан username, он должен совпадать с владельцем токена.
+func authenticatedUser(w http.ResponseWriter, r *http.Request, log *slog.Logger, username string) (
+	entity.User, bool,
+) {
+	user, ok := mw.UserFromContext(r.Context())
+	if !ok {
+		newErrorResponse(w, r, log, nil, http.StatusUnauthorized, MsgUserNotFound)
+		return entity.User{}, true
+	}
+	if !user.IsActive() {
+		newErrorResponse(w, r, log, nil, http.StatusForbidden, MsgUserDeactivated)
+		return entity.User{}, true
+	}
+	if username != "" && username != user.Username {
+		newErrorResponse(w, r, log, nil, http.StatusForbidden, MsgForbidden)
+		return entity.User{}, true
+	}
+	return user, false
+}
+
+// authorize отвечает 403 или 500, если authz.Policy отказала в доступе
+func authorize(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, authz.ErrForbidden) {
+		newErrorResponse(w, r, log, err, http.StatusForbidden, MsgForbidden)
+		return true
+	}
+	newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+	return true
+}
+
+type inputAuthToken struct {
+	Username string `json:"username" validate:"required"`
+}
+
+type outputAuthToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// token выдает dev-токен по имени пользователя
+func (a *authRoutes) token(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input inputAuthToken
+		var err error
+
+		if err = render.DecodeJSON(r.Body, &input); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgFailedParsing)
+			return
+		}
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, err := a.userService.GetByUsername(ctx, log, service.UserGetByUsernameInput{Username: input.Username})
+		if err != nil {
+			if err == service.ErrUserNotFound {
+				newErrorResponse(w, r, log, err, http.StatusUnauthorized, MsgUserNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+		if !user.IsActive() {
+			newErrorResponse(w, r, log, service.ErrUserDeactivated, http.StatusForbidden, MsgUserDeactivated)
+			return
+		}
+
+		signed, expiresAt, err := a.tokens.Issue(user.Id)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, outputAuthToken{Token: signed, ExpiresAt: expiresAt})
+	}
+}
@@ -2,12 +2,15 @@ package v1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"time"
 
+	"tender-service/internal/audit"
+	"tender-service/internal/authz"
 	"tender-service/internal/entity"
 	"tender-service/internal/service"
 
@@ -17,81 +20,49 @@ import (
 )
 
 const (
-	bidPath        = "/bids"
-	usernameMethod = "user"
-	idMethod       = "id"
+	bidPath = "/bids"
+
+	tenderStatusPublished = "Published"
 )
 
 type bidRoutes struct {
-	userService    service.User
-	tenderService  service.Tender
-	orgResponsible service.OrgResponsible
-	bidService     service.Bid
+	userService      service.User
+	tenderService    service.Tender
+	orgResponsible   service.OrgResponsible
+	bidService       service.Bid
+	bidReviewService service.BidReview
+	policy           *authz.Policy
+	recorder         *audit.Recorder
 }
 
 func newBidRoutes(
 	ctx context.Context, log *slog.Logger, route chi.Router, userService service.User, tenderService service.Tender,
-	orgResponsible service.OrgResponsible, bidService service.Bid,
+	orgResponsible service.OrgResponsible, bidService service.Bid, bidReviewService service.BidReview,
+	policy *authz.Policy, recorder *audit.Recorder,
 ) {
 	u := bidRoutes{
 		userService: userService, tenderService: tenderService, orgResponsible: orgResponsible, bidService: bidService,
+		bidReviewService: bidReviewService, policy: policy, recorder: recorder,
 	}
 	route.Route(
 		bidPath, func(r chi.Router) {
 			r.Post("/new", u.create(ctx, log))
 			r.Get("/my", u.getMy(ctx, log))
+			r.Get("/search", u.search(ctx, log))
 			r.Get("/{tenderId}/list", u.getList(ctx, log))
 			r.Get("/{bidId}/status", u.getStatus(ctx, log))
 			r.Put("/{bidId}/status", u.setStatus(ctx, log))
 			r.Patch("/{bidId}/edit", u.edit(ctx, log))
+			r.Put("/{bidId}/rollback/{version}", u.rollback(ctx, log))
+			r.Get("/{bidId}/versions", u.getVersions(ctx, log))
+			r.Get("/{bidId}/diff", u.diff(ctx, log))
+			r.Put("/{bidId}/submit_decision", u.submitDecision(ctx, log))
+			r.Put("/{bidId}/feedback", u.feedback(ctx, log))
+			r.Get("/{tenderId}/reviews", u.getReviews(ctx, log))
 		},
 	)
 }
 
-// IsUserOrgResponsible проверка является ли пользователь ответственный за организацию
-func (u *bidRoutes) IsUserOrgResponsible(
-	w http.ResponseWriter, r *http.Request, err error, ctx context.Context, log *slog.Logger,
-	organizationId, userId string,
-) (error, bool) {
-	if _, err = u.orgResponsible.GetByIds(
-		ctx, log, service.OrgResponsibleGetByIdsInput{
-			OrganizationId: organizationId,
-			UserId:         userId,
-		},
-	); err != nil {
-		newErrorResponse(w, r, log, err, http.StatusForbidden, MsgForbidden)
-		return nil, true
-	}
-	return err, false
-}
-
-// IsExistUser существует ли пользователь
-func (u *bidRoutes) IsExistUser(
-	w http.ResponseWriter, r *http.Request, err error, ctx context.Context, log *slog.Logger, data string,
-	method string,
-) (entity.User, error, bool) {
-	var user entity.User
-	switch method {
-	case idMethod:
-		user, err = u.userService.GetById(ctx, log, service.UserGetByIdInput{Id: data})
-	case usernameMethod:
-		user, err = u.userService.GetByUsername(
-			ctx,
-			log,
-			service.UserGetByUsernameInput{Username: data},
-		)
-	}
-	if err != nil {
-		if err == service.ErrUserNotFound {
-			newErrorResponse(w, r, log, err, http.StatusUnauthorized, MsgUserNotFound)
-			return entity.User{}, nil, true
-		}
-		newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
-		return entity.User{}, nil, true
-	}
-	return user, err, false
-}
-
 type inputBidCreate struct {
 	Name        string `json:"name" validate:"required"`
 	Description string `json:"description" validate:"required"`
@@ -127,11 +98,34 @@ func (u *bidRoutes) create(ctx context.Context, log *slog.Logger) http.HandlerFu
 			return
 		}
 
-		_, err, done = u.IsExistUser(w, r, err, ctx, log, input.AuthorId, idMethod)
+		user, done := authenticatedUser(w, r, log, "")
 		if done {
 			return
 		}
 
+		if authorize(
+			w, r, log, u.policy.CanCreateBid(
+				ctx, user, entity.Bid{AuthorType: input.AuthorType, AuthorId: input.AuthorId},
+			),
+		) {
+			return
+		}
+
+		var t entity.Tender
+		if t, err = u.tenderService.GetById(ctx, log, input.TenderId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
+			return
+		}
+
+		// предложения принимает только опубликованный тендер
+		if authorize(w, r, log, u.policy.CanViewTender(ctx, user, t)) {
+			return
+		}
+		if t.Status != tenderStatusPublished {
+			newErrorResponse(w, r, log, nil, http.StatusBadRequest, MsgTenderNotPublished)
+			return
+		}
+
 		// создание предложения
 		var res entity.Bid
 		if res, err = u.bidService.Create(
@@ -151,13 +145,18 @@ func (u *bidRoutes) create(ctx context.Context, log *slog.Logger) http.HandlerFu
 			return
 		}
 
-		if _, err = u.tenderService.GetById(ctx, log, input.TenderId); err != nil {
-			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
-			return
-		}
+		u.recorder.Record(
+			r.Context(), log, audit.Entry{
+				Action:     entity.AuditActionCreate,
+				EntityType: entity.AuditEntityBid,
+				EntityId:   res.Id,
+				OldVersion: 0,
+				NewVersion: res.Version,
+			},
+		)
 
 		output := bidOutput{
-			Id:          res.AuthorId,
+			Id:          res.Id,
 			Name:        res.Name,
 			Description: res.Description,
 			Status:      res.Status,
@@ -176,7 +175,7 @@ func (u *bidRoutes) create(ctx context.Context, log *slog.Logger) http.HandlerFu
 type inputBidGetMy struct {
 	Limit    int    `validate:"omitempty,number,gte=0,lte=50"`
 	Offset   int    `validate:"omitempty,number,gte=0"`
-	Username string `validate:"required"`
+	Username string `validate:"omitempty"`
 }
 
 func (u *bidRoutes) getMy(ctx context.Context, log *slog.Logger) http.HandlerFunc {
@@ -223,16 +222,23 @@ func (u *bidRoutes) getMy(ctx context.Context, log *slog.Logger) http.HandlerFun
 			return
 		}
 
-		user, err, done := u.IsExistUser(w, r, err, ctx, log, input.Username, usernameMethod)
+		after, err := parseCursor(r, "")
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidCursor)
+			return
+		}
+
+		user, done := authenticatedUser(w, r, log, input.Username)
 		if done {
 			return
 		}
 
-		var bids []entity.Bid
+		var bids entity.Page[entity.Bid]
 		if bids, err = u.bidService.GetMy(
 			ctx, log, service.BidGetMyInput{
 				Limit:  input.Limit,
 				Offset: input.Offset,
+				After:  after,
 				UserId: user.Id,
 			},
 		); err != nil {
@@ -240,7 +246,7 @@ func (u *bidRoutes) getMy(ctx context.Context, log *slog.Logger) http.HandlerFun
 			return
 		}
 		var output []bidOutput
-		for _, t := range bids {
+		for _, t := range bids.Items {
 			out := bidOutput{
 				Id:          t.Id,
 				Name:        t.Name,
@@ -254,6 +260,85 @@ func (u *bidRoutes) getMy(ctx context.Context, log *slog.Logger) http.HandlerFun
 			}
 			output = append(output, out)
 		}
+		setNextCursor(w, r, bids.Next, "")
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputBidSearch struct {
+	Query    string `validate:"required,max=200"`
+	Limit    int    `validate:"omitempty,number,gte=0,lte=50"`
+	Offset   int    `validate:"omitempty,number,gte=0"`
+	Username string `validate:"omitempty"`
+}
+
+// search полнотекстовый поиск по названию и описанию видимых пользователю предложений
+func (u *bidRoutes) search(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			limit  int
+			offset int
+			err    error
+		)
+		if l := r.URL.Query().Get("limit"); len(l) != 0 {
+			if limit, err = strconv.Atoi(l); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+		if off := r.URL.Query().Get("offset"); len(off) != 0 {
+			if offset, err = strconv.Atoi(off); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+
+		input := inputBidSearch{
+			Query:    r.URL.Query().Get("q"),
+			Limit:    limit,
+			Offset:   offset,
+			Username: r.URL.Query().Get("username"),
+		}
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done := authenticatedUser(w, r, log, input.Username)
+		if done {
+			return
+		}
+
+		bids, err := u.bidService.Search(
+			ctx, log, service.BidSearchInput{
+				Limit:  input.Limit,
+				Offset: input.Offset,
+				Query:  input.Query,
+				UserId: user.Id,
+			},
+		)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := make([]bidOutput, 0, len(bids))
+		for _, t := range bids {
+			output = append(
+				output, bidOutput{
+					Id:          t.Id,
+					Name:        t.Name,
+					Description: t.Description,
+					Status:      t.Status,
+					TenderId:    t.TenderId,
+					AuthorType:  t.AuthorType,
+					AuthorId:    t.AuthorId,
+					Version:     t.Version,
+					CreatedAt:   t.CreatedAt,
+				},
+			)
+		}
 		w.WriteHeader(http.StatusOK)
 		render.JSON(w, r, output)
 	}
@@ -263,7 +348,7 @@ type inputBidGetList struct {
 	TenderId string `validate:"required,uuid"`
 	Limit    int    `validate:"omitempty,number,gte=0,lte=50"`
 	Offset   int    `validate:"omitempty,number,gte=0"`
-	Username string `validate:"required"`
+	Username string `validate:"omitempty"`
 }
 
 func (u *bidRoutes) getList(ctx context.Context, log *slog.Logger) http.HandlerFunc {
@@ -314,16 +399,28 @@ func (u *bidRoutes) getList(ctx context.Context, log *slog.Logger) http.HandlerF
 			return
 		}
 
-		user, err, done := u.IsExistUser(w, r, err, ctx, log, input.Username, usernameMethod)
+		after, err := parseCursor(r, "")
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidCursor)
+			return
+		}
+
+		user, done := authenticatedUser(w, r, log, input.Username)
 		if done {
 			return
 		}
 
-		var bids []entity.Bid
+		if _, err = u.tenderService.GetById(ctx, log, input.TenderId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
+			return
+		}
+
+		var bids entity.Page[entity.Bid]
 		if bids, err = u.bidService.GetByTenderId(
 			ctx, log, service.BidGetByTenderIdInput{
 				Limit:    input.Limit,
 				Offset:   input.Offset,
+				After:    after,
 				UserId:   user.Id,
 				TenderId: tenderId,
 			},
@@ -332,7 +429,7 @@ func (u *bidRoutes) getList(ctx context.Context, log *slog.Logger) http.HandlerF
 			return
 		}
 		var output []bidOutput
-		for _, t := range bids {
+		for _, t := range bids.Items {
 			out := bidOutput{
 				Id:          t.Id,
 				Name:        t.Name,
@@ -346,6 +443,7 @@ func (u *bidRoutes) getList(ctx context.Context, log *slog.Logger) http.HandlerF
 			}
 			output = append(output, out)
 		}
+		setNextCursor(w, r, bids.Next, "")
 		w.WriteHeader(http.StatusOK)
 		render.JSON(w, r, output)
 	}
@@ -353,7 +451,7 @@ func (u *bidRoutes) getList(ctx context.Context, log *slog.Logger) http.HandlerF
 
 type inputBidGetStatus struct {
 	BidId    string `validate:"required,uuid"`
-	Username string `validate:"required"`
+	Username string `validate:"omitempty"`
 }
 
 func (u *bidRoutes) getStatus(ctx context.Context, log *slog.Logger) http.HandlerFunc {
@@ -375,7 +473,7 @@ func (u *bidRoutes) getStatus(ctx context.Context, log *slog.Logger) http.Handle
 			return
 		}
 
-		user, err, done = u.IsExistUser(w, r, err, ctx, log, input.Username, usernameMethod)
+		user, done = authenticatedUser(w, r, log, input.Username)
 		if done {
 			return
 		}
@@ -386,14 +484,7 @@ func (u *bidRoutes) getStatus(ctx context.Context, log *slog.Logger) http.Handle
 			return
 		}
 
-		t, err := u.tenderService.GetById(ctx, log, output.TenderId)
-		if err != nil {
-			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
-			return
-		}
-
-		err, done = u.IsUserOrgResponsible(w, r, err, ctx, log, t.OrganizationId, user.Id)
-		if done {
+		if authorize(w, r, log, u.policy.CanViewBid(ctx, user, output)) {
 			return
 		}
 
@@ -408,8 +499,8 @@ func (u *bidRoutes) getStatus(ctx context.Context, log *slog.Logger) http.Handle
 
 type bidSetStatusInput struct {
 	BidId    string `validate:"required,uuid"`
-	Status   string `validate:"required,oneof=Created Published Closed"`
-	Username string `validate:"required"`
+	Status   string `validate:"required,oneof=Created Published Canceled"`
+	Username string `validate:"omitempty"`
 }
 
 func (u *bidRoutes) setStatus(ctx context.Context, log *slog.Logger) http.HandlerFunc {
@@ -432,33 +523,51 @@ func (u *bidRoutes) setStatus(ctx context.Context, log *slog.Logger) http.Handle
 			return
 		}
 
-		user, err, done = u.IsExistUser(w, r, err, ctx, log, input.Username, usernameMethod)
-		if done {
+		var expectedVersion int
+		if expectedVersion, err = parseExpectedVersion(r); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidVersion)
 			return
 		}
 
-		var b entity.Bid
-		if b, err = u.bidService.GetById(ctx, log, input.BidId); err != nil {
-			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
+		user, done = authenticatedUser(w, r, log, input.Username)
+		if done {
 			return
 		}
 
-		t, err := u.tenderService.GetById(ctx, log, b.TenderId)
-		if err != nil {
-			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
+		var b entity.Bid
+		if b, err = u.bidService.GetById(ctx, log, input.BidId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidNotFound)
 			return
 		}
 
-		err, done = u.IsUserOrgResponsible(w, r, err, ctx, log, t.OrganizationId, user.Id)
-		if done {
+		if authorize(w, r, log, u.policy.CanEditBid(ctx, user, b)) {
 			return
 		}
 
-		if out, err = u.bidService.PutStatus(ctx, log, input.BidId, input.Status); err != nil {
+		if out, err = u.bidService.PutStatus(ctx, log, input.BidId, input.Status, expectedVersion); err != nil {
+			if err == service.ErrBidDecided {
+				newErrorResponse(w, r, log, err, http.StatusConflict, MsgBidDecided)
+				return
+			}
+			var conflict *service.VersionConflictError
+			if errors.As(err, &conflict) {
+				newVersionConflictResponse(w, r, log, err, conflict.CurrentVersion)
+				return
+			}
 			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
 			return
 		}
 
+		u.recorder.Record(
+			r.Context(), log, audit.Entry{
+				Action:     entity.AuditActionStatus,
+				EntityType: entity.AuditEntityBid,
+				EntityId:   out.Id,
+				OldVersion: out.Version - 1,
+				NewVersion: out.Version,
+			},
+		)
+
 		output := bidOutput{
 			Id:          out.Id,
 			Name:        out.Name,
@@ -471,6 +580,7 @@ func (u *bidRoutes) setStatus(ctx context.Context, log *slog.Logger) http.Handle
 			CreatedAt:   out.CreatedAt,
 		}
 
+		setETag(w, out.Version)
 		w.WriteHeader(http.StatusOK)
 		render.JSON(w, r, output)
 	}
@@ -478,7 +588,7 @@ func (u *bidRoutes) setStatus(ctx context.Context, log *slog.Logger) http.Handle
 
 type editParamsInputBid struct {
 	BidId    string `validate:"required,uuid"`
-	Username string `validate:"required"`
+	Username string `validate:"omitempty"`
 }
 
 type editBodyInputBid struct {
@@ -492,6 +602,7 @@ func (u *bidRoutes) edit(ctx context.Context, log *slog.Logger) http.HandlerFunc
 		var (
 			err  error
 			out  entity.Bid
+			user entity.User
 			done bool
 		)
 
@@ -516,22 +627,146 @@ func (u *bidRoutes) edit(ctx context.Context, log *slog.Logger) http.HandlerFunc
 			return
 		}
 
-		_, err, done = u.IsExistUser(w, r, err, ctx, log, inputParams.Username, usernameMethod)
+		var expectedVersion int
+		if expectedVersion, err = parseExpectedVersion(r); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidVersion)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, inputParams.Username)
 		if done {
 			return
 		}
 
+		var b entity.Bid
+		if b, err = u.bidService.GetById(ctx, log, inputParams.BidId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidNotFound)
+			return
+		}
+
+		if authorize(w, r, log, u.policy.CanEditBid(ctx, user, b)) {
+			return
+		}
+
 		if out, err = u.bidService.EditBid(
 			ctx,
 			log, service.BidEditInput{
-				Name:        inputBody.Name,
-				Description: inputBody.Description,
+				Name:            inputBody.Name,
+				Description:     inputBody.Description,
+				ExpectedVersion: expectedVersion,
 			}, inputParams.BidId,
 		); err != nil {
+			if err == service.ErrBidDecided {
+				newErrorResponse(w, r, log, err, http.StatusConflict, MsgBidDecided)
+				return
+			}
+			var conflict *service.VersionConflictError
+			if errors.As(err, &conflict) {
+				newVersionConflictResponse(w, r, log, err, conflict.CurrentVersion)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidNotFound)
+			return
+		}
+
+		u.recorder.Record(
+			r.Context(), log, audit.Entry{
+				Action:     entity.AuditActionEdit,
+				EntityType: entity.AuditEntityBid,
+				EntityId:   out.Id,
+				OldVersion: out.Version - 1,
+				NewVersion: out.Version,
+			},
+		)
+
+		output := bidOutput{
+			Id:          out.Id,
+			Name:        out.Name,
+			Description: out.Description,
+			Status:      out.Status,
+			TenderId:    out.TenderId,
+			AuthorType:  out.AuthorType,
+			AuthorId:    out.AuthorId,
+			Version:     out.Version,
+			CreatedAt:   out.CreatedAt,
+		}
+
+		setETag(w, out.Version)
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputBidRollback struct {
+	BidId    string `validate:"required,uuid"`
+	Version  int    `validate:"required,gte=1"`
+	Username string `validate:"omitempty"`
+}
+
+func (u *bidRoutes) rollback(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err     error
+			out     entity.Bid
+			user    entity.User
+			version int
+			done    bool
+		)
+
+		if version, err = strconv.Atoi(chi.URLParam(r, "version")); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+			return
+		}
+
+		input := inputBidRollback{
+			BidId:    chi.URLParam(r, "bidId"),
+			Version:  version,
+			Username: r.URL.Query().Get("username"),
+		}
+
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, input.Username)
+		if done {
+			return
+		}
+
+		var b entity.Bid
+		if b, err = u.bidService.GetById(ctx, log, input.BidId); err != nil {
 			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidNotFound)
 			return
 		}
 
+		if authorize(w, r, log, u.policy.CanEditBid(ctx, user, b)) {
+			return
+		}
+
+		if out, err = u.bidService.Rollback(ctx, log, input.BidId, input.Version); err != nil {
+			if err == service.ErrBidDecided {
+				newErrorResponse(w, r, log, err, http.StatusConflict, MsgBidDecided)
+				return
+			}
+			if err == service.ErrBidVersionNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidVersionNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		u.recorder.Record(
+			r.Context(), log, audit.Entry{
+				Action:     entity.AuditActionRollback,
+				EntityType: entity.AuditEntityBid,
+				EntityId:   out.Id,
+				OldVersion: b.Version,
+				NewVersion: out.Version,
+			},
+		)
+
 		output := bidOutput{
 			Id:          out.Id,
 			Name:        out.Name,
@@ -548,3 +783,413 @@ func (u *bidRoutes) edit(ctx context.Context, log *slog.Logger) http.HandlerFunc
 		render.JSON(w, r, output)
 	}
 }
+
+// canViewBidHistory история предложения доступна автору и ответственным за организацию тендера
+func (u *bidRoutes) canViewBidHistory(
+	w http.ResponseWriter, r *http.Request, ctx context.Context, log *slog.Logger, bidId string, user entity.User,
+) bool {
+	b, err := u.bidService.GetById(ctx, log, bidId)
+	if err != nil {
+		newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidNotFound)
+		return false
+	}
+	return !authorize(w, r, log, u.policy.CanViewBid(ctx, user, b))
+}
+
+type inputBidVersions struct {
+	BidId    string `validate:"required,uuid"`
+	Username string `validate:"omitempty"`
+}
+
+func (u *bidRoutes) getVersions(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err      error
+			user     entity.User
+			versions []entity.Bid
+			done     bool
+		)
+
+		input := inputBidVersions{
+			BidId:    chi.URLParam(r, "bidId"),
+			Username: r.URL.Query().Get("username"),
+		}
+
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, input.Username)
+		if done {
+			return
+		}
+
+		if !u.canViewBidHistory(w, r, ctx, log, input.BidId, user) {
+			return
+		}
+
+		if versions, err = u.bidService.GetVersions(ctx, log, input.BidId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := make([]bidOutput, 0, len(versions))
+		for _, v := range versions {
+			output = append(
+				output, bidOutput{
+					Id:          v.Id,
+					Name:        v.Name,
+					Description: v.Description,
+					Status:      v.Status,
+					TenderId:    v.TenderId,
+					AuthorType:  v.AuthorType,
+					AuthorId:    v.AuthorId,
+					Version:     v.Version,
+					CreatedAt:   v.CreatedAt,
+				},
+			)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputBidDiff struct {
+	BidId    string `validate:"required,uuid"`
+	From     int    `validate:"required,gte=1"`
+	To       int    `validate:"required,gte=1"`
+	Username string `validate:"omitempty"`
+}
+
+func (u *bidRoutes) diff(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err     error
+			user    entity.User
+			from    int
+			to      int
+			changes []entity.FieldDiff
+			done    bool
+		)
+
+		if from, err = strconv.Atoi(r.URL.Query().Get("from")); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+			return
+		}
+		if to, err = strconv.Atoi(r.URL.Query().Get("to")); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+			return
+		}
+
+		input := inputBidDiff{
+			BidId:    chi.URLParam(r, "bidId"),
+			From:     from,
+			To:       to,
+			Username: r.URL.Query().Get("username"),
+		}
+
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, input.Username)
+		if done {
+			return
+		}
+
+		if !u.canViewBidHistory(w, r, ctx, log, input.BidId, user) {
+			return
+		}
+
+		if changes, err = u.bidService.Diff(ctx, log, input.BidId, input.From, input.To); err != nil {
+			if err == service.ErrBidVersionNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidVersionNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, newOutputDiff(input.From, input.To, changes))
+	}
+}
+
+type inputBidSubmitDecision struct {
+	BidId    string `validate:"required,uuid"`
+	Decision string `validate:"required,oneof=Approved Rejected"`
+	Username string `validate:"omitempty"`
+}
+
+func (u *bidRoutes) submitDecision(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			out  service.BidSubmitDecisionOutput
+			user entity.User
+			done bool
+		)
+
+		input := inputBidSubmitDecision{
+			BidId:    chi.URLParam(r, "bidId"),
+			Decision: r.URL.Query().Get("decision"),
+			Username: r.URL.Query().Get("username"),
+		}
+
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, input.Username)
+		if done {
+			return
+		}
+
+		if out, err = u.bidService.SubmitDecision(
+			ctx, log, service.BidSubmitDecisionInput{
+				BidId:    input.BidId,
+				User:     user,
+				Decision: input.Decision,
+			},
+		); err != nil {
+			switch err {
+			case service.ErrBidNotFound:
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidNotFound)
+			case service.ErrForbidden:
+				newErrorResponse(w, r, log, err, http.StatusForbidden, MsgForbidden)
+			case service.ErrBidDecisionAlreadyExists:
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgBidDecisionAlreadyExists)
+			case service.ErrBidNotDecidable:
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgBidNotDecidable)
+			default:
+				newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			}
+			return
+		}
+
+		u.recorder.Record(
+			r.Context(), log, audit.Entry{
+				Action:     entity.AuditActionDecision,
+				EntityType: entity.AuditEntityBid,
+				EntityId:   out.Bid.Id,
+				OldVersion: out.BidOldVersion,
+				NewVersion: out.Bid.Version,
+			},
+		)
+		if out.TenderClosed {
+			u.recorder.Record(
+				r.Context(), log, audit.Entry{
+					Action:     entity.AuditActionStatus,
+					EntityType: entity.AuditEntityTender,
+					EntityId:   out.Bid.TenderId,
+					OldVersion: out.TenderOldVersion,
+					NewVersion: out.TenderNewVersion,
+				},
+			)
+		}
+
+		output := bidOutput{
+			Id:          out.Bid.Id,
+			Name:        out.Bid.Name,
+			Description: out.Bid.Description,
+			Status:      out.Bid.Status,
+			TenderId:    out.Bid.TenderId,
+			AuthorType:  out.Bid.AuthorType,
+			AuthorId:    out.Bid.AuthorId,
+			Version:     out.Bid.Version,
+			CreatedAt:   out.Bid.CreatedAt,
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputBidFeedback struct {
+	BidId       string `validate:"required,uuid"`
+	BidFeedback string `validate:"required,max=1000"`
+	Username    string `validate:"required"`
+}
+
+func (u *bidRoutes) feedback(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err  error
+			user entity.User
+			done bool
+		)
+
+		input := inputBidFeedback{
+			BidId:       chi.URLParam(r, "bidId"),
+			BidFeedback: r.URL.Query().Get("bidFeedback"),
+			Username:    r.URL.Query().Get("username"),
+		}
+
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, input.Username)
+		if done {
+			return
+		}
+
+		var b entity.Bid
+		if b, err = u.bidService.GetById(ctx, log, input.BidId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidNotFound)
+			return
+		}
+
+		if _, err = u.bidReviewService.Create(
+			ctx, log, service.BidReviewCreateInput{
+				Description: input.BidFeedback,
+				BidId:       b.Id,
+				User:        user,
+			},
+		); err != nil {
+			switch err {
+			case service.ErrForbidden:
+				newErrorResponse(w, r, log, err, http.StatusForbidden, MsgForbidden)
+			case service.ErrBidNotFound:
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidNotFound)
+			case service.ErrBidReviewAlreadyExists:
+				newErrorResponse(w, r, log, err, http.StatusConflict, MsgBidReviewAlreadyExists)
+			default:
+				newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			}
+			return
+		}
+
+		output := bidOutput{
+			Id:          b.Id,
+			Name:        b.Name,
+			Description: b.Description,
+			Status:      b.Status,
+			TenderId:    b.TenderId,
+			AuthorType:  b.AuthorType,
+			AuthorId:    b.AuthorId,
+			Version:     b.Version,
+			CreatedAt:   b.CreatedAt,
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputBidGetReviews struct {
+	TenderId          string `validate:"required,uuid"`
+	AuthorUsername    string `validate:"required"`
+	RequesterUsername string `validate:"omitempty"`
+	OrganizationId    string `validate:"omitempty,uuid"`
+	Limit             int    `validate:"omitempty,number,gte=0,lte=50"`
+	Offset            int    `validate:"omitempty,number,gte=0"`
+}
+
+type bidReviewOutput struct {
+	Id          string    `json:"id"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func (u *bidRoutes) getReviews(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			limit   int
+			offset  int
+			err     error
+			reviews []entity.BidReview
+		)
+
+		l := r.URL.Query().Get("limit")
+		if len(l) != 0 {
+			if limit, err = strconv.Atoi(l); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+
+		off := r.URL.Query().Get("offset")
+		if len(off) != 0 {
+			if offset, err = strconv.Atoi(off); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+
+		input := inputBidGetReviews{
+			TenderId:          chi.URLParam(r, "tenderId"),
+			AuthorUsername:    r.URL.Query().Get("authorUsername"),
+			RequesterUsername: r.URL.Query().Get("requesterUsername"),
+			OrganizationId:    r.URL.Query().Get("organizationId"),
+			Limit:             limit,
+			Offset:            offset,
+		}
+
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		requester, done := authenticatedUser(w, r, log, input.RequesterUsername)
+		if done {
+			return
+		}
+
+		t, err := u.tenderService.GetById(ctx, log, input.TenderId)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
+			return
+		}
+
+		if authorize(w, r, log, u.policy.CanViewTenderHistory(ctx, requester, t)) {
+			return
+		}
+
+		// автор может быть деактивирован: его история отзывов остается доступной ответственным
+		author, err := u.userService.GetByUsername(ctx, log, service.UserGetByUsernameInput{Username: input.AuthorUsername})
+		if err != nil {
+			if err == service.ErrUserNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgUserNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		if reviews, err = u.bidReviewService.GetByAuthor(
+			ctx, log, service.BidReviewGetByAuthorInput{
+				Limit:          input.Limit,
+				Offset:         input.Offset,
+				TenderId:       t.Id,
+				AuthorId:       author.Id,
+				OrganizationId: input.OrganizationId,
+			},
+		); err != nil {
+			if err == service.ErrBidNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgBidReviewNotFound)
+			return
+		}
+
+		output := make([]bidReviewOutput, 0, len(reviews))
+		for _, rv := range reviews {
+			output = append(
+				output, bidReviewOutput{
+					Id:          rv.Id,
+					Description: rv.Description,
+					CreatedAt:   rv.CreatedAt,
+				},
+			)
+		}
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
@@ -0,0 +1,199 @@
+package v1_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+type bidOutput struct {
+	Id      string `json:"id"`
+	Status  string `json:"status"`
+	Version int    `json:"version"`
+}
+
+// newPublishedTender создает и публикует тендер организации orgId от имени ответственного
+func (s *testServer) newPublishedTender(t *testing.T, bearer, orgId string) string {
+	t.Helper()
+
+	var tender tenderOutput
+	body := map[string]string{
+		"name": "tender", "description": "description", "serviceType": "Construction", "organizationId": orgId,
+	}
+	if code := s.do(t, http.MethodPost, "/api/tenders/new", bearer, body, &tender); code != http.StatusOK {
+		t.Fatalf("create tender: status %d", code)
+	}
+	path := "/api/tenders/" + tender.Id + "/status?status=Published"
+	if code := s.do(t, http.MethodPut, path, bearer, nil, nil); code != http.StatusOK {
+		t.Fatalf("publish tender: status %d", code)
+	}
+	return tender.Id
+}
+
+// newPublishedBid создает и публикует предложение пользователя authorId на тендер tenderId
+func (s *testServer) newPublishedBid(t *testing.T, bearer, authorId, tenderId string) bidOutput {
+	t.Helper()
+
+	var bid bidOutput
+	body := map[string]string{
+		"name": "bid", "description": "description", "tenderId": tenderId, "authorType": "User", "authorId": authorId,
+	}
+	if code := s.do(t, http.MethodPost, "/api/bids/new", bearer, body, &bid); code != http.StatusOK {
+		t.Fatalf("create bid: status %d", code)
+	}
+	if code := s.do(t, http.MethodPut, "/api/bids/"+bid.Id+"/status?status=Published", bearer, nil, &bid); code != http.StatusOK {
+		t.Fatalf("publish bid: status %d", code)
+	}
+	return bid
+}
+
+func TestBidDecidedIsFinal(t *testing.T) {
+	for _, decision := range []string{"Approved", "Rejected"} {
+		t.Run(decision, func(t *testing.T) {
+			s := newTestServer(t)
+			_, owner := s.newUser(t, "owner")
+			authorId, author := s.newUser(t, "author")
+			tenderId := s.newPublishedTender(t, owner, s.newOrganization(t, owner))
+			bid := s.newPublishedBid(t, author, authorId, tenderId)
+
+			// единственный ответственный составляет кворум
+			var decided bidOutput
+			path := "/api/bids/" + bid.Id + "/submit_decision?decision=" + decision
+			if code := s.do(t, http.MethodPut, path, owner, nil, &decided); code != http.StatusOK {
+				t.Fatalf("submit decision: status %d", code)
+			}
+			if decided.Status != decision {
+				t.Fatalf("bid status %s after decision, want %s", decided.Status, decision)
+			}
+
+			tests := []struct {
+				name   string
+				method string
+				path   string
+				body   any
+			}{
+				{"status", http.MethodPut, "/api/bids/" + bid.Id + "/status?status=Published", nil},
+				{"edit", http.MethodPatch, "/api/bids/" + bid.Id + "/edit", map[string]string{"name": "renamed"}},
+				{"rollback", http.MethodPut, "/api/bids/" + bid.Id + "/rollback/1", nil},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					if code := s.do(t, tt.method, tt.path, author, tt.body, nil); code != http.StatusConflict {
+						t.Fatalf("status %d, want %d", code, http.StatusConflict)
+					}
+				})
+			}
+
+			var status struct {
+				Status string `json:"status"`
+			}
+			if code := s.do(t, http.MethodGet, "/api/bids/"+bid.Id+"/status", author, nil, &status); code != http.StatusOK {
+				t.Fatalf("get status: status %d", code)
+			}
+			if status.Status != decision {
+				t.Fatalf("bid status changed to %s, want %s", status.Status, decision)
+			}
+		})
+	}
+}
+
+func TestBidCreateRequiresPublishedTender(t *testing.T) {
+	s := newTestServer(t)
+	ownerId, owner := s.newUser(t, "owner")
+	authorId, author := s.newUser(t, "author")
+	orgId := s.newOrganization(t, owner)
+
+	var tender tenderOutput
+	tenderBody := map[string]string{
+		"name": "tender", "description": "description", "serviceType": "Construction", "organizationId": orgId,
+	}
+	if code := s.do(t, http.MethodPost, "/api/tenders/new", owner, tenderBody, &tender); code != http.StatusOK {
+		t.Fatalf("create tender: status %d", code)
+	}
+	bidBody := func(authorId string) map[string]string {
+		return map[string]string{
+			"name": "bid", "description": "description", "tenderId": tender.Id, "authorType": "User", "authorId": authorId,
+		}
+	}
+	setStatus := func(status string) {
+		t.Helper()
+		path := "/api/tenders/" + tender.Id + "/status?status=" + status
+		if code := s.do(t, http.MethodPut, path, owner, nil, nil); code != http.StatusOK {
+			t.Fatalf("set tender status %s: status %d", status, code)
+		}
+	}
+
+	// черновик виден только ответственным, остальным он недоступен
+	if code := s.do(t, http.MethodPost, "/api/bids/new", author, bidBody(authorId), nil); code != http.StatusForbidden {
+		t.Fatalf("bid on draft by outsider: status %d, want %d", code, http.StatusForbidden)
+	}
+	if code := s.do(t, http.MethodPost, "/api/bids/new", owner, bidBody(ownerId), nil); code != http.StatusBadRequest {
+		t.Fatalf("bid on draft by responsible: status %d, want %d", code, http.StatusBadRequest)
+	}
+
+	setStatus("Published")
+	if code := s.do(t, http.MethodPost, "/api/bids/new", author, bidBody(authorId), nil); code != http.StatusOK {
+		t.Fatalf("bid on published tender: status %d", code)
+	}
+
+	setStatus("Closed")
+	if code := s.do(t, http.MethodPost, "/api/bids/new", owner, bidBody(ownerId), nil); code != http.StatusBadRequest {
+		t.Fatalf("bid on closed tender: status %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestSubmitDecisionUnknownBid(t *testing.T) {
+	s := newTestServer(t)
+	_, owner := s.newUser(t, "owner")
+
+	path := "/api/bids/00000000-0000-0000-0000-000000000000/submit_decision?decision=Approved"
+	if code := s.do(t, http.MethodPut, path, owner, nil, nil); code != http.StatusNotFound {
+		t.Fatalf("submit decision on unknown bid: status %d, want %d", code, http.StatusNotFound)
+	}
+}
+
+func TestQuorumCloseAuditsTender(t *testing.T) {
+	s := newTestServer(t)
+	_, owner := s.newUser(t, "owner")
+	authorId, author := s.newUser(t, "author")
+	tenderId := s.newPublishedTender(t, owner, s.newOrganization(t, owner))
+	bid := s.newPublishedBid(t, author, authorId, tenderId)
+
+	myTender := func() tenderOutput {
+		t.Helper()
+		var my []tenderOutput
+		if code := s.do(t, http.MethodGet, "/api/tenders/my", owner, nil, &my); code != http.StatusOK || len(my) != 1 {
+			t.Fatalf("list my tenders: status %d, %d tenders", code, len(my))
+		}
+		return my[0]
+	}
+	before := myTender()
+
+	path := "/api/bids/" + bid.Id + "/submit_decision?decision=Approved"
+	if code := s.do(t, http.MethodPut, path, owner, nil, nil); code != http.StatusOK {
+		t.Fatalf("submit decision: status %d", code)
+	}
+
+	after := myTender()
+	if after.Status != "Closed" {
+		t.Fatalf("tender status %s after quorum, want Closed", after.Status)
+	}
+
+	// записи журнала идут от новых к старым, закрытие тендера - последнее изменение
+	var entries []struct {
+		Action     string `json:"action"`
+		EntityType string `json:"entityType"`
+		OldVersion *int   `json:"oldVersion"`
+		NewVersion *int   `json:"newVersion"`
+	}
+	if code := s.do(t, http.MethodGet, "/api/audit?entityId="+tenderId, owner, nil, &entries); code != http.StatusOK {
+		t.Fatalf("get audit: status %d", code)
+	}
+	if len(entries) == 0 {
+		t.Fatal("no audit entries for tender")
+	}
+	got := entries[0]
+	if got.Action != "status" || got.EntityType != "tender" || got.OldVersion == nil || got.NewVersion == nil ||
+		*got.OldVersion != before.Version || *got.NewVersion != after.Version {
+		t.Fatalf("last tender audit entry %+v, want status change %d -> %d", got, before.Version, after.Version)
+	}
+}
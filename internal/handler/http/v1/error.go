@@ -13,25 +13,44 @@ import (
 var (
 	MsgInvalidReq        = "Invalid request"
 	MsgFailedParsing     = "Failed to parse data"
+	MsgInvalidCursor     = "Invalid cursor"
+	MsgInvalidSort       = "Invalid sort: allowed fields are name, createdAt, version"
+	MsgInvalidVersion    = "Invalid If-Match or expectedVersion"
+	MsgVersionConflict   = "Version has changed since it was read"
 	MsgInternalServerErr = "Internal server error"
 
 	MsgOrgNotFound     = "Organization not found"
 	MsgUserNotFound    = "User not found"
+	MsgUserDeactivated = "User is deactivated"
 	MsgOrgRespNotFound = "User is not responsible for the company"
 	MsgTenderNotFound  = "Tender not found"
 	MsgBidNotFound     = "Bid not found"
+	MsgWebhookNotFound = "Webhook not found"
 
-	MsgForbidden           = "Forbidden"
-	MsgUserAlreadyExists   = "User already exists"
-	MsgOrgAlreadyExists    = "Organization already exists"
-	MsgTenderAlreadyExists = "Tender already exists"
-	MsgBidAlreadyExists    = "Bid already exists"
+	MsgTenderVersionNotFound = "Tender version not found"
+	MsgBidVersionNotFound    = "Bid version not found"
+
+	MsgForbidden                = "Forbidden"
+	MsgUserAlreadyExists        = "User already exists"
+	MsgOrgAlreadyExists         = "Organization already exists"
+	MsgOrgRespAlreadyExists     = "User is already responsible for the organization"
+	MsgUserResponsibleElsewhere = "User is already responsible for another organization"
+	MsgLastOrgResponsible       = "Cannot remove the last responsible of the organization"
+	MsgTenderAlreadyExists      = "Tender already exists"
+	MsgBidAlreadyExists         = "Bid already exists"
+
+	MsgBidDecisionAlreadyExists = "Decision on this bid has already been submitted by the user"
+	MsgBidNotDecidable          = "Bid or tender is not published"
+	MsgTenderNotPublished       = "Tender is not published"
+	MsgBidDecided               = "Bid is already approved or rejected and cannot be changed"
+	MsgBidReviewNotFound        = "Bid reviews not found"
+	MsgBidReviewAlreadyExists   = "Review on this bid has already been submitted by the user"
 )
 
 func newErrorResponse(
 	w http.ResponseWriter, r *http.Request, log *slog.Logger, err error, errStatus int, message string,
 ) {
-	log.Error(message, err)
+	log.Error(message, slog.Any("error", err))
 	w.WriteHeader(errStatus)
 	render.JSON(w, r, response.MakeResponse(message))
 }
@@ -43,7 +62,7 @@ func newErrorValidateResponse(
 	var validateErr validator.ValidationErrors
 	errors.As(err, &validateErr)
 
-	log.Error(message, err)
+	log.Error(message, slog.Any("error", err))
 	w.WriteHeader(errStatus)
 	render.JSON(w, r, response.ValidationError(validateErr))
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"tender-service/internal/authz"
 	"tender-service/internal/service"
 )
 
@@ -17,16 +18,25 @@ const (
 
 type orgRespRoutes struct {
 	orgRespService service.OrgResponsible
+	policy         *authz.Policy
 }
 
+// newOrgRespRoutes добавить ответственного может только ответственный за ту же организацию (auth)
 func newOrgRespRoutes(
 	ctx context.Context, log *slog.Logger, route chi.Router, orgRespService service.OrgResponsible,
+	policy *authz.Policy, auth func(http.Handler) http.Handler,
 ) {
-	o := orgRespRoutes{orgRespService: orgRespService}
+	o := orgRespRoutes{orgRespService: orgRespService, policy: policy}
 	route.Route(
 		orgRespString, func(r chi.Router) {
-			r.Post("/create", o.create(ctx, log))
 			r.Get("/{id}", o.get(ctx, log))
+
+			r.Group(
+				func(r chi.Router) {
+					r.Use(auth)
+					r.Post("/create", o.create(ctx, log))
+				},
+			)
 		},
 	)
 }
@@ -56,6 +66,14 @@ func (o *orgRespRoutes) create(ctx context.Context, log *slog.Logger) http.Handl
 			return
 		}
 
+		user, done := authenticatedUser(w, r, log, "")
+		if done {
+			return
+		}
+		if authorize(w, r, log, o.policy.CanManageOrganization(ctx, user, input.OrganizationId)) {
+			return
+		}
+
 		result, err := o.orgRespService.Create(
 			ctx, log, service.OrgResponsibleCreateInput{
 				OrganizationId: input.OrganizationId,
@@ -63,8 +81,7 @@ func (o *orgRespRoutes) create(ctx context.Context, log *slog.Logger) http.Handl
 			},
 		)
 		if err != nil {
-			if err == service.ErrOrgAlreadyExists {
-				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgOrgAlreadyExists)
+			if orgRespConflict(w, r, log, err) {
 				return
 			}
 			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
@@ -122,3 +139,16 @@ func (o *orgRespRoutes) get(ctx context.Context, log *slog.Logger) http.HandlerF
 		)
 	}
 }
+
+// orgRespConflict отвечает 409, если пользователь уже ответственный за эту или другую организацию
+func orgRespConflict(w http.ResponseWriter, r *http.Request, log *slog.Logger, err error) bool {
+	switch err {
+	case service.ErrOrgRespAlreadyExists:
+		newErrorResponse(w, r, log, err, http.StatusConflict, MsgOrgRespAlreadyExists)
+		return true
+	case service.ErrUserResponsibleElsewhere:
+		newErrorResponse(w, r, log, err, http.StatusConflict, MsgUserResponsibleElsewhere)
+		return true
+	}
+	return false
+}
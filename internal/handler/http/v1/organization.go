@@ -4,11 +4,14 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"tender-service/internal/authz"
+	"tender-service/internal/entity"
 	"tender-service/internal/service"
 )
 
@@ -17,15 +20,47 @@ const (
 )
 
 type orgRoutes struct {
-	orgService service.Organization
+	orgService     service.Organization
+	orgRespService service.OrgResponsible
+	userService    service.User
+	webhookService service.Webhook
+	policy         *authz.Policy
 }
 
-func newOrgRoutes(ctx context.Context, log *slog.Logger, route chi.Router, orgService service.Organization) {
-	o := orgRoutes{orgService: orgService}
+// newOrgRoutes чтение организаций открыто, создание и изменения требуют токена (auth);
+// создатель организации становится первым ответственным за нее
+func newOrgRoutes(
+	ctx context.Context, log *slog.Logger, route chi.Router, orgService service.Organization,
+	orgRespService service.OrgResponsible, userService service.User, webhookService service.Webhook,
+	policy *authz.Policy, auth func(http.Handler) http.Handler,
+) {
+	o := orgRoutes{
+		orgService:     orgService,
+		orgRespService: orgRespService,
+		userService:    userService,
+		webhookService: webhookService,
+		policy:         policy,
+	}
 	route.Route(
 		orgString, func(r chi.Router) {
-			r.Post("/create", o.create(ctx, log))
+			r.Get("/", o.list(ctx, log))
 			r.Get("/{id}", o.get(ctx, log))
+			r.Get("/{id}/responsibles", o.responsibles(ctx, log))
+
+			r.Group(
+				func(r chi.Router) {
+					r.Use(auth)
+					r.Post("/create", o.create(ctx, log))
+					r.Patch("/{id}", o.update(ctx, log))
+					r.Delete("/{id}", o.delete(ctx, log))
+					r.Post("/{id}/responsibles", o.addResponsible(ctx, log))
+					r.Delete("/{id}/responsibles/{userId}", o.removeResponsible(ctx, log))
+					r.Post("/{id}/webhooks", o.createWebhook(ctx, log))
+					r.Get("/{id}/webhooks", o.webhooks(ctx, log))
+					r.Delete("/{id}/webhooks/{webhookId}", o.deleteWebhook(ctx, log))
+					r.Get("/{id}/webhooks/{webhookId}/deliveries", o.webhookDeliveries(ctx, log))
+				},
+			)
 		},
 	)
 }
@@ -57,11 +92,17 @@ func (o *orgRoutes) create(ctx context.Context, log *slog.Logger) http.HandlerFu
 			return
 		}
 
+		user, done := authenticatedUser(w, r, log, "")
+		if done {
+			return
+		}
+
 		result, err := o.orgService.Create(
 			ctx, log, service.OrganizationCreateInput{
 				Name:             input.Name,
 				Description:      input.Description,
 				OrganizationType: input.OrganizationType,
+				CreatorId:        user.Id,
 			},
 		)
 		if err != nil {
@@ -69,6 +110,9 @@ func (o *orgRoutes) create(ctx context.Context, log *slog.Logger) http.HandlerFu
 				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgOrgAlreadyExists)
 				return
 			}
+			if orgRespConflict(w, r, log, err) {
+				return
+			}
 			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
 			return
 		}
@@ -131,3 +175,366 @@ func (o *orgRoutes) get(ctx context.Context, log *slog.Logger) http.HandlerFunc
 		)
 	}
 }
+
+func newOutputOrgGet(o entity.Organization) outputOrgGet {
+	return outputOrgGet{
+		Id:               o.Id,
+		Name:             o.Name,
+		Description:      o.Description,
+		OrganizationType: o.OrganizationType,
+		CreatedAt:        o.CreatedAt,
+		UpdatedAt:        o.UpdatedAt,
+	}
+}
+
+type inputOrgList struct {
+	Limit  int    `validate:"omitempty,number,gte=0,lte=50"`
+	Offset int    `validate:"omitempty,number,gte=0"`
+	Name   string `validate:"omitempty,max=100"`
+}
+
+// list список организаций с поиском по названию (?name=)
+func (o *orgRoutes) list(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			limit  int
+			offset int
+			err    error
+		)
+		if l := r.URL.Query().Get("limit"); len(l) != 0 {
+			if limit, err = strconv.Atoi(l); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+		if off := r.URL.Query().Get("offset"); len(off) != 0 {
+			if offset, err = strconv.Atoi(off); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+
+		input := inputOrgList{
+			Limit:  limit,
+			Offset: offset,
+			Name:   r.URL.Query().Get("name"),
+		}
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		result, err := o.orgService.GetList(
+			ctx, log, service.OrganizationGetListInput{
+				Limit:  input.Limit,
+				Offset: input.Offset,
+				Name:   input.Name,
+			},
+		)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := make([]outputOrgGet, 0, len(result))
+		for _, v := range result {
+			output = append(output, newOutputOrgGet(v))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputOrgUpdate struct {
+	Id               string `validate:"uuid"`
+	Name             string `json:"name" validate:"omitempty,max=100"`
+	Description      string `json:"description" validate:"omitempty"`
+	OrganizationType string `json:"type" validate:"omitempty,oneof=IE LLC JSC"`
+}
+
+// update частичное изменение организации, updated_at обновляется
+func (o *orgRoutes) update(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input inputOrgUpdate
+		var err error
+
+		if err = render.DecodeJSON(r.Body, &input); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgFailedParsing)
+			return
+		}
+		input.Id = chi.URLParam(r, "id")
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		if _, done := o.manageableOrg(ctx, w, r, log, input.Id); done {
+			return
+		}
+
+		result, err := o.orgService.Update(
+			ctx, log, service.OrganizationUpdateInput{
+				Id:               input.Id,
+				Name:             input.Name,
+				Description:      input.Description,
+				OrganizationType: input.OrganizationType,
+			},
+		)
+		if err != nil {
+			if err == service.ErrOrgNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgOrgNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, newOutputOrgGet(result))
+	}
+}
+
+// delete мягкое удаление организации, ее тендеры сохраняются
+func (o *orgRoutes) delete(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var err error
+
+		id := chi.URLParam(r, "id")
+		if err = validator.New().Struct(inputOrgGet{Id: id}); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		org, done := o.manageableOrg(ctx, w, r, log, id)
+		if done {
+			return
+		}
+
+		if err = o.orgService.Delete(ctx, log, service.OrganizationDeleteInput{Id: id}); err != nil {
+			if err == service.ErrOrgNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgOrgNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, newOutputOrgGet(org))
+	}
+}
+
+type inputOrgResponsibles struct {
+	Id     string `validate:"uuid"`
+	Limit  int    `validate:"omitempty,number,gte=0,lte=50"`
+	Offset int    `validate:"omitempty,number,gte=0"`
+}
+
+// responsibles список ответственных за организацию
+func (o *orgRoutes) responsibles(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			limit  int
+			offset int
+			err    error
+		)
+		if l := r.URL.Query().Get("limit"); len(l) != 0 {
+			if limit, err = strconv.Atoi(l); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+		if off := r.URL.Query().Get("offset"); len(off) != 0 {
+			if offset, err = strconv.Atoi(off); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+
+		input := inputOrgResponsibles{
+			Id:     chi.URLParam(r, "id"),
+			Limit:  limit,
+			Offset: offset,
+		}
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		if _, err = o.orgService.Get(ctx, log, service.OrganizationGetInput{Id: input.Id}); err != nil {
+			if err == service.ErrOrgNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgOrgNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		result, err := o.orgRespService.GetByOrganization(
+			ctx, log, service.OrgResponsibleGetByOrganizationInput{
+				Limit:          input.Limit,
+				Offset:         input.Offset,
+				OrganizationId: input.Id,
+			},
+		)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := make([]outputOrgRespGet, 0, len(result))
+		for _, v := range result {
+			output = append(
+				output, outputOrgRespGet{
+					Id:             v.Id,
+					OrganizationId: v.OrganizationId,
+					UserId:         v.UserId,
+				},
+			)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputOrgAddResponsible struct {
+	Id     string `validate:"uuid"`
+	UserId string `json:"user_id" validate:"required,uuid"`
+}
+
+// addResponsible назначает пользователя ответственным за организацию
+func (o *orgRoutes) addResponsible(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input inputOrgAddResponsible
+		var err error
+
+		if err = render.DecodeJSON(r.Body, &input); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgFailedParsing)
+			return
+		}
+		input.Id = chi.URLParam(r, "id")
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		if _, done := o.manageableOrg(ctx, w, r, log, input.Id); done {
+			return
+		}
+
+		responsible, err := o.userService.GetById(ctx, log, service.UserGetByIdInput{Id: input.UserId})
+		if err != nil {
+			if err == service.ErrUserNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgUserNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+		if !responsible.IsActive() {
+			newErrorResponse(w, r, log, service.ErrUserDeactivated, http.StatusBadRequest, MsgUserDeactivated)
+			return
+		}
+
+		result, err := o.orgRespService.Create(
+			ctx, log, service.OrgResponsibleCreateInput{
+				OrganizationId: input.Id,
+				UserId:         input.UserId,
+			},
+		)
+		if err != nil {
+			if orgRespConflict(w, r, log, err) {
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(
+			w, r, outputOrgRespGet{
+				Id:             result.Id,
+				OrganizationId: result.OrganizationId,
+				UserId:         result.UserId,
+			},
+		)
+	}
+}
+
+type inputOrgRemoveResponsible struct {
+	Id     string `validate:"uuid"`
+	UserId string `validate:"uuid"`
+}
+
+// removeResponsible снимает с пользователя ответственность за организацию
+func (o *orgRoutes) removeResponsible(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var err error
+
+		input := inputOrgRemoveResponsible{
+			Id:     chi.URLParam(r, "id"),
+			UserId: chi.URLParam(r, "userId"),
+		}
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		if _, done := o.manageableOrg(ctx, w, r, log, input.Id); done {
+			return
+		}
+
+		if err = o.orgRespService.Delete(
+			ctx, log, service.OrgResponsibleDeleteInput{
+				OrganizationId: input.Id,
+				UserId:         input.UserId,
+			},
+		); err != nil {
+			if err == service.ErrOrgRespNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgOrgRespNotFound)
+				return
+			}
+			if err == service.ErrLastOrgResponsible {
+				newErrorResponse(w, r, log, err, http.StatusConflict, MsgLastOrgResponsible)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(
+			w, r, outputOrgRespGet{
+				OrganizationId: input.Id,
+				UserId:         input.UserId,
+			},
+		)
+	}
+}
+
+// manageableOrg организация, которую вправе менять пользователь из токена
+func (o *orgRoutes) manageableOrg(
+	ctx context.Context, w http.ResponseWriter, r *http.Request, log *slog.Logger, id string,
+) (entity.Organization, bool) {
+	user, done := authenticatedUser(w, r, log, "")
+	if done {
+		return entity.Organization{}, true
+	}
+
+	org, err := o.orgService.Get(ctx, log, service.OrganizationGetInput{Id: id})
+	if err != nil {
+		if err == service.ErrOrgNotFound {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgOrgNotFound)
+			return entity.Organization{}, true
+		}
+		newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+		return entity.Organization{}, true
+	}
+
+	if authorize(w, r, log, o.policy.CanManageOrganization(ctx, user, org.Id)) {
+		return entity.Organization{}, true
+	}
+	return org, false
+}
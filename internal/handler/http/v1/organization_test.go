@@ -0,0 +1,27 @@
+package v1_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRemoveLastResponsible(t *testing.T) {
+	s := newTestServer(t)
+	ownerId, owner := s.newUser(t, "owner")
+	secondId, _ := s.newUser(t, "second")
+	orgId := s.newOrganization(t, owner)
+	path := "/api/org/" + orgId + "/responsibles/"
+
+	// создатель - единственный ответственный, без него организацией некому управлять
+	if code := s.do(t, http.MethodDelete, path+ownerId, owner, nil, nil); code != http.StatusConflict {
+		t.Fatalf("remove last responsible: status %d, want %d", code, http.StatusConflict)
+	}
+
+	body := map[string]string{"user_id": secondId}
+	if code := s.do(t, http.MethodPost, "/api/org/"+orgId+"/responsibles", owner, body, nil); code != http.StatusOK {
+		t.Fatalf("add responsible: status %d", code)
+	}
+	if code := s.do(t, http.MethodDelete, path+ownerId, owner, nil, nil); code != http.StatusOK {
+		t.Fatalf("remove one of two responsibles: status %d", code)
+	}
+}
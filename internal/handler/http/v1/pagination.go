@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"tender-service/internal/entity"
+)
+
+const (
+	cursorParam      = "cursor"
+	sortParam        = "sort"
+	withTotalParam   = "withTotal"
+	nextCursorHeader = "X-Next-Cursor"
+	totalCountHeader = "X-Total-Count"
+)
+
+var (
+	errInvalidCursor = errors.New("invalid cursor")
+	errInvalidSort   = errors.New("invalid sort")
+)
+
+// encodeCursor непрозрачное представление курсора для клиента
+func encodeCursor(c entity.Cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// parseCursor курсор из параметра cursor; nil, если параметр не передан.
+// Курсор должен быть выдан для той же сортировки sort
+func parseCursor(r *http.Request, sort string) (*entity.Cursor, error) {
+	value := r.URL.Query().Get(cursorParam)
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	var c entity.Cursor
+	if err = json.Unmarshal(raw, &c); err != nil {
+		return nil, errInvalidCursor
+	}
+	if err = validator.New().Var(c.Id, "required,uuid"); err != nil {
+		return nil, errInvalidCursor
+	}
+	if c.Sort != sort {
+		return nil, errInvalidCursor
+	}
+	return &c, nil
+}
+
+// setNextCursor выставляет X-Next-Cursor и Link на следующую страницу
+// для сортировки sort. Вызывается до WriteHeader
+func setNextCursor(w http.ResponseWriter, r *http.Request, next *entity.Cursor, sort string) {
+	if next == nil {
+		return
+	}
+	next.Sort = sort
+	cursor := encodeCursor(*next)
+
+	u := *r.URL
+	q := u.Query()
+	q.Set(cursorParam, cursor)
+	q.Del("offset")
+	u.RawQuery = q.Encode()
+
+	w.Header().Set(nextCursorHeader, cursor)
+	w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, u.RequestURI()))
+}
+
+// parseWithTotal нужно ли считать общее число строк (?withTotal=true)
+func parseWithTotal(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get(withTotalParam)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// setTotalCount выставляет X-Total-Count, если общее число строк известно.
+// Вызывается до WriteHeader
+func setTotalCount(w http.ResponseWriter, total *int) {
+	if total == nil {
+		return
+	}
+	w.Header().Set(totalCountHeader, strconv.Itoa(*total))
+}
+
+// parseSort разбирает sort=field[:asc|:desc],... по allow-list allowed.
+// Возвращает поля и каноническую запись сортировки для курсора
+func parseSort(r *http.Request, allowed []string) ([]entity.SortField, string, error) {
+	value := r.URL.Query().Get(sortParam)
+	if value == "" {
+		return nil, "", nil
+	}
+
+	var (
+		fields    []entity.SortField
+		canonical []string
+	)
+	for _, part := range strings.Split(value, ",") {
+		name, direction, _ := strings.Cut(strings.TrimSpace(part), ":")
+		if !slices.Contains(allowed, name) {
+			return nil, "", fmt.Errorf("%w: unknown field %q", errInvalidSort, name)
+		}
+		for _, f := range fields {
+			if f.Field == name {
+				return nil, "", fmt.Errorf("%w: duplicate field %q", errInvalidSort, name)
+			}
+		}
+
+		field := entity.SortField{Field: name}
+		switch strings.ToLower(direction) {
+		case "", "asc":
+		case "desc":
+			field.Desc = true
+		default:
+			return nil, "", fmt.Errorf("%w: unknown direction %q", errInvalidSort, direction)
+		}
+
+		fields = append(fields, field)
+		if field.Desc {
+			canonical = append(canonical, name+":desc")
+		} else {
+			canonical = append(canonical, name)
+		}
+	}
+	return fields, strings.Join(canonical, ","), nil
+}
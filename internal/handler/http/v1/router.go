@@ -5,8 +5,11 @@ import (
 	"log/slog"
 	"net/http"
 
+	"tender-service/internal/entity"
+	"tender-service/internal/metrics"
 	"tender-service/internal/service"
-	mwLogger "tender-service/pkg/middleware"
+	mw "tender-service/pkg/middleware"
+	"tender-service/pkg/token"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -15,22 +18,48 @@ import (
 
 const api = "/api"
 
-func NewRouter(ctx context.Context, log *slog.Logger, route *chi.Mux, services *service.Services) {
+func NewRouter(
+	ctx context.Context, log *slog.Logger, route *chi.Mux, services *service.Services, tokens *token.Manager,
+	m *metrics.Metrics, devTokens bool,
+) {
 	route.Use(middleware.Logger)
 	route.Use(middleware.RequestID)
 	route.Use(middleware.Recoverer)
 	route.Use(middleware.URLFormat)
-	route.Use(mwLogger.New(log))
+	route.Use(mw.New(log))
+	route.Use(mw.Metrics(m))
 	route.Use(render.SetContentType(render.ContentTypeJSON))
 
+	auth := mw.Auth(log, tokens, userResolver(log, services.User))
+
 	route.Route(
 		api, func(r chi.Router) {
 			r.Get("/ping", Ping())
-			newUserRoutes(ctx, log, r, services.User)
-			newOrgRoutes(ctx, log, r, services.Organization)
-			newOrgRespRoutes(ctx, log, r, services.OrgResponsible)
-			newTenderRoutes(ctx, log, r, services.User, services.Tender, services.OrgResponsible)
-			newBidRoutes(ctx, log, r, services.User, services.Tender, services.OrgResponsible, services.Bid)
+			newUserRoutes(ctx, log, r, services.User, auth)
+			newOrgRoutes(
+				ctx, log, r, services.Organization, services.OrgResponsible, services.User, services.Webhook,
+				services.Authz, auth,
+			)
+			newOrgRespRoutes(ctx, log, r, services.OrgResponsible, services.Authz, auth)
+			// выдача токена без проверки учетных данных, только для локальной разработки
+			if devTokens {
+				newAuthRoutes(ctx, log, r, services.User, tokens)
+			}
+
+			r.Group(
+				func(r chi.Router) {
+					r.Use(auth)
+					newTenderRoutes(
+						ctx, log, r, services.User, services.Tender, services.OrgResponsible, services.Authz,
+						services.Recorder,
+					)
+					newBidRoutes(
+						ctx, log, r, services.User, services.Tender, services.OrgResponsible, services.Bid,
+						services.BidReview, services.Authz, services.Recorder,
+					)
+					newAuditRoutes(ctx, log, r, services.Audit)
+				},
+			)
 		},
 	)
 }
@@ -43,3 +72,16 @@ func Ping() http.HandlerFunc {
 
 	}
 }
+
+func userResolver(log *slog.Logger, userService service.User) mw.UserResolver {
+	return func(ctx context.Context, subject string) (entity.User, error) {
+		user, err := userService.GetById(ctx, log, service.UserGetByIdInput{Id: subject})
+		if err != nil {
+			return entity.User{}, err
+		}
+		if !user.IsActive() {
+			return entity.User{}, service.ErrUserDeactivated
+		}
+		return user, nil
+	}
+}
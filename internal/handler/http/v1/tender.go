@@ -2,12 +2,14 @@ package v1
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"time"
 
+	"tender-service/internal/audit"
+	"tender-service/internal/authz"
 	"tender-service/internal/entity"
 	"tender-service/internal/service"
 
@@ -17,83 +19,47 @@ import (
 )
 
 const (
-	tender        = "/tenders"
-	statusCreated = "Created"
-	statusClosed  = "Closed"
+	tender = "/tenders"
 )
 
 type tenderRoutes struct {
 	userService    service.User
 	tenderService  service.Tender
 	orgResponsible service.OrgResponsible
+	policy         *authz.Policy
+	recorder       *audit.Recorder
 }
 
 func newTenderRoutes(
 	ctx context.Context, log *slog.Logger, route chi.Router, userService service.User, tenderService service.Tender,
-	orgResponsible service.OrgResponsible,
+	orgResponsible service.OrgResponsible, policy *authz.Policy, recorder *audit.Recorder,
 ) {
-	u := tenderRoutes{userService: userService, tenderService: tenderService, orgResponsible: orgResponsible}
+	u := tenderRoutes{
+		userService: userService, tenderService: tenderService, orgResponsible: orgResponsible, policy: policy,
+		recorder: recorder,
+	}
 	route.Route(
 		tender, func(r chi.Router) {
 			r.Post("/new", u.create(ctx, log))
 			r.Get("/", u.getByType(ctx, log))
 			r.Get("/my", u.getMy(ctx, log))
+			r.Get("/search", u.search(ctx, log))
 			r.Get("/{tenderId}/status", u.getStatus(ctx, log))
 			r.Put("/{tenderId}/status", u.setStatus(ctx, log))
 			r.Patch("/{tenderId}/edit", u.edit(ctx, log))
+			r.Put("/{tenderId}/rollback/{version}", u.rollback(ctx, log))
+			r.Get("/{tenderId}/versions", u.getVersions(ctx, log))
+			r.Get("/{tenderId}/diff", u.diff(ctx, log))
 		},
 	)
 }
 
-// IsUserOrgResponsible проверка является ли пользователь ответственный за организацию
-func (u *tenderRoutes) IsUserOrgResponsible(
-	w http.ResponseWriter, r *http.Request, err error, ctx context.Context, log *slog.Logger,
-	organizationId, userId string,
-) (error, bool) {
-	if _, err = u.orgResponsible.GetByIds(
-		ctx, log, service.OrgResponsibleGetByIdsInput{
-			OrganizationId: organizationId,
-			UserId:         userId,
-		},
-	); err != nil {
-		//if err == service.ErrOrgRespNotFound {
-		//	newErrorResponse(w, r, log, err, http.StatusForbidden, MsgForbidden)
-		//	return nil, true
-		//}
-		newErrorResponse(w, r, log, err, http.StatusForbidden, MsgForbidden)
-		return nil, true
-	}
-	return err, false
-}
-
-// IsExistUser существует ли пользователь
-func (u *tenderRoutes) IsExistUser(
-	w http.ResponseWriter, r *http.Request, err error, ctx context.Context, log *slog.Logger,
-	username string,
-) (entity.User, error, bool) {
-	log.Info(fmt.Sprintf("IsExistUser"))
-	var user entity.User
-	if user, err = u.userService.GetByUsername(
-		ctx,
-		log,
-		service.UserGetByUsernameInput{Username: username},
-	); err != nil {
-		if err == service.ErrUserNotFound {
-			newErrorResponse(w, r, log, err, http.StatusUnauthorized, MsgUserNotFound)
-			return entity.User{}, nil, true
-		}
-		newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
-		return entity.User{}, nil, true
-	}
-	return user, err, false
-}
-
 type inputTenderCreate struct {
 	Name            string `json:"name" validate:"required"`
 	Description     string `json:"description" validate:"required"`
 	ServiceType     string `json:"serviceType" validate:"required,oneof=Construction Delivery Manufacture"`
 	OrganizationId  string `json:"organizationId" validate:"required,uuid"`
-	CreatorUsername string `json:"creatorUsername" validate:"required"`
+	CreatorUsername string `json:"creatorUsername" validate:"omitempty"`
 }
 
 type outputTenderCreate struct {
@@ -121,13 +87,12 @@ func (u *tenderRoutes) create(ctx context.Context, log *slog.Logger) http.Handle
 			return
 		}
 
-		user, err, done := u.IsExistUser(w, r, err, ctx, log, input.CreatorUsername)
+		user, done := authenticatedUser(w, r, log, input.CreatorUsername)
 		if done {
 			return
 		}
 
-		err, done = u.IsUserOrgResponsible(w, r, err, ctx, log, input.OrganizationId, user.Id)
-		if done {
+		if authorize(w, r, log, u.policy.CanCreateTender(ctx, user, input.OrganizationId)) {
 			return
 		}
 
@@ -139,7 +104,7 @@ func (u *tenderRoutes) create(ctx context.Context, log *slog.Logger) http.Handle
 				Description:     input.Description,
 				ServiceType:     input.ServiceType,
 				OrganizationId:  input.OrganizationId,
-				CreatorUsername: input.CreatorUsername,
+				CreatorUsername: user.Username,
 			},
 		); err != nil {
 			if err == service.ErrTenderAlreadyExists {
@@ -150,6 +115,16 @@ func (u *tenderRoutes) create(ctx context.Context, log *slog.Logger) http.Handle
 			return
 		}
 
+		u.recorder.Record(
+			r.Context(), log, audit.Entry{
+				Action:     entity.AuditActionCreate,
+				EntityType: entity.AuditEntityTender,
+				EntityId:   res.Id,
+				OldVersion: 0,
+				NewVersion: res.Version,
+			},
+		)
+
 		output := outputTenderCreate{
 			Id:          res.Id,
 			Name:        res.Name,
@@ -166,9 +141,25 @@ func (u *tenderRoutes) create(ctx context.Context, log *slog.Logger) http.Handle
 }
 
 type inputGetByType struct {
-	Limit       int      `validate:"omitempty,gte=0,lte=50"`
-	Offset      int      `validate:"omitempty,gte=0"`
-	ServiceType []string `validate:"omitempty,dive,required,oneof=Construction Delivery Manufacture"`
+	Limit           int      `validate:"omitempty,gte=0,lte=50"`
+	Offset          int      `validate:"omitempty,gte=0"`
+	ServiceType     []string `validate:"omitempty,dive,required,oneof=Construction Delivery Manufacture"`
+	Status          []string `validate:"omitempty,dive,required,oneof=Created Published Closed"`
+	OrganizationId  string   `validate:"omitempty,uuid"`
+	CreatorUsername string   `validate:"omitempty,max=50"`
+}
+
+// parseTimeParam время в формате RFC3339 из параметра name; nil, если параметр не передан
+func parseTimeParam(r *http.Request, name string) (*time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
 }
 
 type outputGetByType struct {
@@ -198,38 +189,85 @@ func (u *tenderRoutes) getByType(ctx context.Context, log *slog.Logger) http.Han
 			}
 		}
 
-		if len(r.URL.Query()["limit"]) == 0 {
+		if len(r.URL.Query()["offset"]) == 0 {
 			offset = 0
 		} else {
-			if offset, err = strconv.Atoi(r.URL.Query()["limit"][0]); err != nil {
+			if offset, err = strconv.Atoi(r.URL.Query()["offset"][0]); err != nil {
 				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
 				return
 			}
 		}
 
 		input := inputGetByType{
-			Limit:       limit,
-			Offset:      offset,
-			ServiceType: r.URL.Query()["service_type"],
+			Limit:           limit,
+			Offset:          offset,
+			ServiceType:     r.URL.Query()["service_type"],
+			Status:          r.URL.Query()["status"],
+			OrganizationId:  r.URL.Query().Get("organizationId"),
+			CreatorUsername: r.URL.Query().Get("creatorUsername"),
 		}
 
 		if err = validator.New().Struct(input); err != nil {
 			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
 			return
 		}
-		var tenders []entity.Tender
+
+		createdAfter, err := parseTimeParam(r, "createdAfter")
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+			return
+		}
+		createdBefore, err := parseTimeParam(r, "createdBefore")
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+			return
+		}
+
+		sort, sortKey, err := parseSort(r, entity.TenderSortFields)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidSort)
+			return
+		}
+
+		after, err := parseCursor(r, sortKey)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidCursor)
+			return
+		}
+
+		withTotal, err := parseWithTotal(r)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+			return
+		}
+
+		user, done := authenticatedUser(w, r, log, r.URL.Query().Get("username"))
+		if done {
+			return
+		}
+
+		var tenders entity.Page[entity.Tender]
 		if tenders, err = u.tenderService.GetByType(
 			ctx, log, service.TenderGetByTypeInput{
-				Limit:       input.Limit,
-				Offset:      input.Offset,
-				ServiceType: input.ServiceType,
+				Limit:           input.Limit,
+				Offset:          input.Offset,
+				After:           after,
+				WithTotal:       withTotal,
+				Sort:            sort,
+				ServiceType:     input.ServiceType,
+				Status:          input.Status,
+				OrganizationId:  input.OrganizationId,
+				CreatorUsername: input.CreatorUsername,
+				CreatedAfter:    createdAfter,
+				CreatedBefore:   createdBefore,
+				UserId:          user.Id,
 			},
 		); err != nil {
 			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
 			return
 		}
 		var output []outputGetByType
-		for _, t := range tenders {
+		for _, t := range tenders.Items {
 			out := outputGetByType{
 				Id:          t.Id,
 				Name:        t.Name,
@@ -241,6 +279,8 @@ func (u *tenderRoutes) getByType(ctx context.Context, log *slog.Logger) http.Han
 			}
 			output = append(output, out)
 		}
+		setNextCursor(w, r, tenders.Next, sortKey)
+		setTotalCount(w, tenders.Total)
 		w.WriteHeader(http.StatusOK)
 		render.JSON(w, r, output)
 	}
@@ -249,7 +289,7 @@ func (u *tenderRoutes) getByType(ctx context.Context, log *slog.Logger) http.Han
 type inputTenderGetMy struct {
 	Limit    int    `validate:"omitempty,number,gte=0,lte=50"`
 	Offset   int    `validate:"omitempty,number,gte=0"`
-	Username string `validate:"required"`
+	Username string `validate:"omitempty"`
 }
 
 type outputTenderGetMy struct {
@@ -306,24 +346,31 @@ func (u *tenderRoutes) getMy(ctx context.Context, log *slog.Logger) http.Handler
 			return
 		}
 
-		_, err, done := u.IsExistUser(w, r, err, ctx, log, input.Username)
+		after, err := parseCursor(r, "")
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidCursor)
+			return
+		}
+
+		user, done := authenticatedUser(w, r, log, input.Username)
 		if done {
 			return
 		}
 
-		var tenders []entity.Tender
+		var tenders entity.Page[entity.Tender]
 		if tenders, err = u.tenderService.GetMy(
 			ctx, log, service.TenderGetMyInput{
 				Limit:    input.Limit,
 				Offset:   input.Offset,
-				Username: input.Username,
+				After:    after,
+				Username: user.Username,
 			},
 		); err != nil {
 			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
 			return
 		}
 		var output []outputTenderGetMy
-		for _, t := range tenders {
+		for _, t := range tenders.Items {
 			out := outputTenderGetMy{
 				Id:          t.Id,
 				Name:        t.Name,
@@ -335,6 +382,83 @@ func (u *tenderRoutes) getMy(ctx context.Context, log *slog.Logger) http.Handler
 			}
 			output = append(output, out)
 		}
+		setNextCursor(w, r, tenders.Next, "")
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputTenderSearch struct {
+	Query    string `validate:"required,max=200"`
+	Limit    int    `validate:"omitempty,number,gte=0,lte=50"`
+	Offset   int    `validate:"omitempty,number,gte=0"`
+	Username string `validate:"omitempty"`
+}
+
+// search полнотекстовый поиск по названию и описанию видимых пользователю тендеров
+func (u *tenderRoutes) search(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			limit  int
+			offset int
+			err    error
+		)
+		if l := r.URL.Query().Get("limit"); len(l) != 0 {
+			if limit, err = strconv.Atoi(l); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+		if off := r.URL.Query().Get("offset"); len(off) != 0 {
+			if offset, err = strconv.Atoi(off); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+
+		input := inputTenderSearch{
+			Query:    r.URL.Query().Get("q"),
+			Limit:    limit,
+			Offset:   offset,
+			Username: r.URL.Query().Get("username"),
+		}
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done := authenticatedUser(w, r, log, input.Username)
+		if done {
+			return
+		}
+
+		tenders, err := u.tenderService.Search(
+			ctx, log, service.TenderSearchInput{
+				Limit:  input.Limit,
+				Offset: input.Offset,
+				Query:  input.Query,
+				UserId: user.Id,
+			},
+		)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := make([]outputGetByType, 0, len(tenders))
+		for _, t := range tenders {
+			output = append(
+				output, outputGetByType{
+					Id:          t.Id,
+					Name:        t.Name,
+					Description: t.Description,
+					Status:      t.Status,
+					ServiceType: t.ServiceType,
+					Version:     t.Version,
+					CreatedAt:   t.CreatedAt,
+				},
+			)
+		}
 		w.WriteHeader(http.StatusOK)
 		render.JSON(w, r, output)
 	}
@@ -342,7 +466,7 @@ func (u *tenderRoutes) getMy(ctx context.Context, log *slog.Logger) http.Handler
 
 type inputGetStatus struct {
 	TenderId string `validate:"required,uuid"`
-	Username string `validate:"required"`
+	Username string `validate:"omitempty"`
 }
 
 func (u *tenderRoutes) getStatus(ctx context.Context, log *slog.Logger) http.HandlerFunc {
@@ -364,7 +488,7 @@ func (u *tenderRoutes) getStatus(ctx context.Context, log *slog.Logger) http.Han
 			return
 		}
 
-		user, err, done = u.IsExistUser(w, r, err, ctx, log, input.Username)
+		user, done = authenticatedUser(w, r, log, input.Username)
 		if done {
 			return
 		}
@@ -375,14 +499,8 @@ func (u *tenderRoutes) getStatus(ctx context.Context, log *slog.Logger) http.Han
 			return
 		}
 
-		switch output.Status {
-		case statusCreated, statusClosed:
-			err, done = u.IsUserOrgResponsible(w, r, err, ctx, log, output.OrganizationId, user.Id)
-			if done {
-				return
-			}
-		default:
-			break
+		if authorize(w, r, log, u.policy.CanViewTender(ctx, user, output)) {
+			return
 		}
 
 		type status struct {
@@ -397,7 +515,7 @@ func (u *tenderRoutes) getStatus(ctx context.Context, log *slog.Logger) http.Han
 type inputSetStatus struct {
 	TenderId string `validate:"required,uuid"`
 	Status   string `validate:"required,oneof=Created Published Closed"`
-	Username string `validate:"required"`
+	Username string `validate:"omitempty"`
 }
 
 type outputSetStatus struct {
@@ -430,7 +548,13 @@ func (u *tenderRoutes) setStatus(ctx context.Context, log *slog.Logger) http.Han
 			return
 		}
 
-		user, err, done = u.IsExistUser(w, r, err, ctx, log, input.Username)
+		var expectedVersion int
+		if expectedVersion, err = parseExpectedVersion(r); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidVersion)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, input.Username)
 		if done {
 			return
 		}
@@ -441,16 +565,30 @@ func (u *tenderRoutes) setStatus(ctx context.Context, log *slog.Logger) http.Han
 			return
 		}
 
-		err, done = u.IsUserOrgResponsible(w, r, err, ctx, log, t.OrganizationId, user.Id)
-		if done {
+		if authorize(w, r, log, u.policy.CanEditTender(ctx, user, t)) {
 			return
 		}
 
-		if out, err = u.tenderService.PutStatus(ctx, log, input.TenderId, input.Status); err != nil {
+		if out, err = u.tenderService.PutStatus(ctx, log, input.TenderId, input.Status, expectedVersion); err != nil {
+			var conflict *service.VersionConflictError
+			if errors.As(err, &conflict) {
+				newVersionConflictResponse(w, r, log, err, conflict.CurrentVersion)
+				return
+			}
 			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
 			return
 		}
 
+		u.recorder.Record(
+			r.Context(), log, audit.Entry{
+				Action:     entity.AuditActionStatus,
+				EntityType: entity.AuditEntityTender,
+				EntityId:   out.Id,
+				OldVersion: out.Version - 1,
+				NewVersion: out.Version,
+			},
+		)
+
 		output := outputSetStatus{
 			Id:          out.Id,
 			Name:        out.Name,
@@ -461,6 +599,7 @@ func (u *tenderRoutes) setStatus(ctx context.Context, log *slog.Logger) http.Han
 			CreatedAt:   out.CreatedAt,
 		}
 
+		setETag(w, out.Version)
 		w.WriteHeader(http.StatusOK)
 		render.JSON(w, r, output)
 	}
@@ -468,7 +607,7 @@ func (u *tenderRoutes) setStatus(ctx context.Context, log *slog.Logger) http.Han
 
 type editParamsInput struct {
 	TenderId string `validate:"required,uuid"`
-	Username string `validate:"required"`
+	Username string `validate:"omitempty"`
 }
 
 type editBodyInput struct {
@@ -492,6 +631,7 @@ func (u *tenderRoutes) edit(ctx context.Context, log *slog.Logger) http.HandlerF
 		var (
 			err  error
 			out  entity.Tender
+			user entity.User
 			done bool
 		)
 
@@ -516,23 +656,55 @@ func (u *tenderRoutes) edit(ctx context.Context, log *slog.Logger) http.HandlerF
 			return
 		}
 
-		_, err, done = u.IsExistUser(w, r, err, ctx, log, inputParams.Username)
+		var expectedVersion int
+		if expectedVersion, err = parseExpectedVersion(r); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidVersion)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, inputParams.Username)
 		if done {
 			return
 		}
 
+		var t entity.Tender
+		if t, err = u.tenderService.GetById(ctx, log, inputParams.TenderId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
+			return
+		}
+
+		if authorize(w, r, log, u.policy.CanEditTender(ctx, user, t)) {
+			return
+		}
+
 		if out, err = u.tenderService.EditTender(
 			ctx,
 			log, service.TenderEditInput{
-				Name:        inputBody.Name,
-				Description: inputBody.Description,
-				ServiceType: inputBody.ServiceType,
+				Name:            inputBody.Name,
+				Description:     inputBody.Description,
+				ServiceType:     inputBody.ServiceType,
+				ExpectedVersion: expectedVersion,
 			}, inputParams.TenderId,
 		); err != nil {
+			var conflict *service.VersionConflictError
+			if errors.As(err, &conflict) {
+				newVersionConflictResponse(w, r, log, err, conflict.CurrentVersion)
+				return
+			}
 			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
 			return
 		}
 
+		u.recorder.Record(
+			r.Context(), log, audit.Entry{
+				Action:     entity.AuditActionEdit,
+				EntityType: entity.AuditEntityTender,
+				EntityId:   out.Id,
+				OldVersion: out.Version - 1,
+				NewVersion: out.Version,
+			},
+		)
+
 		output := outputEditTender{
 			Id:          out.Id,
 			Name:        out.Name,
@@ -543,7 +715,251 @@ func (u *tenderRoutes) edit(ctx context.Context, log *slog.Logger) http.HandlerF
 			CreatedAt:   out.CreatedAt,
 		}
 
+		setETag(w, out.Version)
 		w.WriteHeader(http.StatusOK)
 		render.JSON(w, r, output)
 	}
 }
+
+type inputTenderRollback struct {
+	TenderId string `validate:"required,uuid"`
+	Version  int    `validate:"required,gte=1"`
+	Username string `validate:"omitempty"`
+}
+
+func (u *tenderRoutes) rollback(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err     error
+			out     entity.Tender
+			user    entity.User
+			version int
+			done    bool
+		)
+
+		if version, err = strconv.Atoi(chi.URLParam(r, "version")); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+			return
+		}
+
+		input := inputTenderRollback{
+			TenderId: chi.URLParam(r, "tenderId"),
+			Version:  version,
+			Username: r.URL.Query().Get("username"),
+		}
+
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, input.Username)
+		if done {
+			return
+		}
+
+		var t entity.Tender
+		if t, err = u.tenderService.GetById(ctx, log, input.TenderId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
+			return
+		}
+
+		if authorize(w, r, log, u.policy.CanEditTender(ctx, user, t)) {
+			return
+		}
+
+		if out, err = u.tenderService.Rollback(ctx, log, input.TenderId, input.Version); err != nil {
+			if err == service.ErrTenderVersionNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderVersionNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		u.recorder.Record(
+			r.Context(), log, audit.Entry{
+				Action:     entity.AuditActionRollback,
+				EntityType: entity.AuditEntityTender,
+				EntityId:   out.Id,
+				OldVersion: t.Version,
+				NewVersion: out.Version,
+			},
+		)
+
+		output := outputSetStatus{
+			Id:          out.Id,
+			Name:        out.Name,
+			Description: out.Description,
+			Status:      out.Status,
+			ServiceType: out.ServiceType,
+			Version:     out.Version,
+			CreatedAt:   out.CreatedAt,
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputTenderVersions struct {
+	TenderId string `validate:"required,uuid"`
+	Username string `validate:"omitempty"`
+}
+
+func (u *tenderRoutes) getVersions(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err      error
+			user     entity.User
+			versions []entity.Tender
+			done     bool
+		)
+
+		input := inputTenderVersions{
+			TenderId: chi.URLParam(r, "tenderId"),
+			Username: r.URL.Query().Get("username"),
+		}
+
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, input.Username)
+		if done {
+			return
+		}
+
+		var t entity.Tender
+		if t, err = u.tenderService.GetById(ctx, log, input.TenderId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
+			return
+		}
+
+		if authorize(w, r, log, u.policy.CanViewTenderHistory(ctx, user, t)) {
+			return
+		}
+
+		if versions, err = u.tenderService.GetVersions(ctx, log, input.TenderId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := make([]outputGetByType, 0, len(versions))
+		for _, v := range versions {
+			output = append(
+				output, outputGetByType{
+					Id:          v.Id,
+					Name:        v.Name,
+					Description: v.Description,
+					Status:      v.Status,
+					ServiceType: v.ServiceType,
+					Version:     v.Version,
+					CreatedAt:   v.CreatedAt,
+				},
+			)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputTenderDiff struct {
+	TenderId string `validate:"required,uuid"`
+	From     int    `validate:"required,gte=1"`
+	To       int    `validate:"required,gte=1"`
+	Username string `validate:"omitempty"`
+}
+
+type outputFieldDiff struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type outputDiff struct {
+	From    int               `json:"from"`
+	To      int               `json:"to"`
+	Changes []outputFieldDiff `json:"changes"`
+}
+
+func (u *tenderRoutes) diff(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			err     error
+			user    entity.User
+			from    int
+			to      int
+			changes []entity.FieldDiff
+			done    bool
+		)
+
+		if from, err = strconv.Atoi(r.URL.Query().Get("from")); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+			return
+		}
+		if to, err = strconv.Atoi(r.URL.Query().Get("to")); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+			return
+		}
+
+		input := inputTenderDiff{
+			TenderId: chi.URLParam(r, "tenderId"),
+			From:     from,
+			To:       to,
+			Username: r.URL.Query().Get("username"),
+		}
+
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		user, done = authenticatedUser(w, r, log, input.Username)
+		if done {
+			return
+		}
+
+		var t entity.Tender
+		if t, err = u.tenderService.GetById(ctx, log, input.TenderId); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderNotFound)
+			return
+		}
+
+		if authorize(w, r, log, u.policy.CanViewTenderHistory(ctx, user, t)) {
+			return
+		}
+
+		if changes, err = u.tenderService.Diff(ctx, log, input.TenderId, input.From, input.To); err != nil {
+			if err == service.ErrTenderVersionNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgTenderVersionNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, newOutputDiff(input.From, input.To, changes))
+	}
+}
+
+func newOutputDiff(from, to int, changes []entity.FieldDiff) outputDiff {
+	output := outputDiff{
+		From:    from,
+		To:      to,
+		Changes: make([]outputFieldDiff, 0, len(changes)),
+	}
+	for _, c := range changes {
+		output.Changes = append(
+			output.Changes, outputFieldDiff{
+				Field: c.Field,
+				From:  c.From,
+				To:    c.To,
+			},
+		)
+	}
+	return output
+}
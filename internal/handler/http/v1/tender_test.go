@@ -0,0 +1,245 @@
+package v1_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	v1 "tender-service/internal/handler/http/v1"
+	"tender-service/internal/metrics"
+	"tender-service/internal/repo/memory"
+	"tender-service/internal/service"
+	"tender-service/pkg/token"
+)
+
+type testServer struct {
+	*httptest.Server
+	tokens *token.Manager
+}
+
+// newTestServer роутер поверх хранилища в памяти, как при storage: memory
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	m := metrics.New()
+	services := service.NewServices(service.ServicesDependencies{
+		Repos: memory.NewRepositories(), SearchLanguage: "russian", Metrics: m,
+	})
+	tokens := token.New("test-signing-key", time.Hour)
+
+	router := chi.NewRouter()
+	v1.NewRouter(context.Background(), log, router, services, tokens, m, false)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return &testServer{Server: server, tokens: tokens}
+}
+
+// do выполняет запрос и декодирует JSON-ответ в out, если он передан
+func (s *testServer) do(t *testing.T, method, path, bearer string, body, out any) int {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("json.Marshal: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, s.URL+path, reader)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode == http.StatusOK {
+		if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: decode: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// newUser создает пользователя и выдает ему токен, возвращает id и токен
+func (s *testServer) newUser(t *testing.T, username string) (string, string) {
+	t.Helper()
+
+	var user struct {
+		Id string `json:"id"`
+	}
+	body := map[string]string{"username": username, "first_name": "First", "last_name": "Last"}
+	if code := s.do(t, http.MethodPost, "/api/user/create", "", body, &user); code != http.StatusOK {
+		t.Fatalf("create user %s: status %d", username, code)
+	}
+
+	signed, _, err := s.tokens.Issue(user.Id)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	return user.Id, signed
+}
+
+// newOrganization создает организацию, создатель становится ее ответственным
+func (s *testServer) newOrganization(t *testing.T, bearer string) string {
+	t.Helper()
+
+	var org struct {
+		Id string `json:"id"`
+	}
+	body := map[string]string{"name": "org", "description": "description", "type": "LLC"}
+	if code := s.do(t, http.MethodPost, "/api/org/create", bearer, body, &org); code != http.StatusOK {
+		t.Fatalf("create organization: status %d", code)
+	}
+	return org.Id
+}
+
+type tenderOutput struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	ServiceType string `json:"serviceType"`
+	Version     int    `json:"version"`
+}
+
+func TestTenderCreateListRoundTrip(t *testing.T) {
+	s := newTestServer(t)
+	_, owner := s.newUser(t, "owner")
+	_, outsider := s.newUser(t, "outsider")
+	orgId := s.newOrganization(t, owner)
+
+	tenderBody := map[string]string{
+		"name": "Доставка бетона", "description": "description", "serviceType": "Delivery", "organizationId": orgId,
+	}
+	if code := s.do(t, http.MethodPost, "/api/tenders/new", "", tenderBody, nil); code != http.StatusUnauthorized {
+		t.Fatalf("create tender without token: status %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := s.do(t, http.MethodPost, "/api/tenders/new", outsider, tenderBody, nil); code != http.StatusForbidden {
+		t.Fatalf("create tender by outsider: status %d, want %d", code, http.StatusForbidden)
+	}
+
+	var created tenderOutput
+	if code := s.do(t, http.MethodPost, "/api/tenders/new", owner, tenderBody, &created); code != http.StatusOK {
+		t.Fatalf("create tender: status %d", code)
+	}
+	if created.Id == "" || created.Status != "Created" || created.Version != 1 {
+		t.Fatalf("created tender %+v, want status Created and version 1", created)
+	}
+
+	var my []tenderOutput
+	if code := s.do(t, http.MethodGet, "/api/tenders/my", owner, nil, &my); code != http.StatusOK {
+		t.Fatalf("list my tenders: status %d", code)
+	}
+	if len(my) != 1 || my[0] != created {
+		t.Fatalf("my tenders %+v, want [%+v]", my, created)
+	}
+
+	// неопубликованный тендер не виден постороннему
+	var list []tenderOutput
+	if code := s.do(t, http.MethodGet, "/api/tenders?service_type=Delivery", outsider, nil, &list); code != http.StatusOK {
+		t.Fatalf("list tenders: status %d", code)
+	}
+	if len(list) != 0 {
+		t.Fatalf("outsider sees unpublished tenders %+v", list)
+	}
+
+	var published tenderOutput
+	path := "/api/tenders/" + created.Id + "/status?status=Published"
+	if code := s.do(t, http.MethodPut, path, owner, nil, &published); code != http.StatusOK {
+		t.Fatalf("publish tender: status %d", code)
+	}
+
+	if code := s.do(t, http.MethodGet, "/api/tenders?service_type=Delivery", outsider, nil, &list); code != http.StatusOK {
+		t.Fatalf("list tenders: status %d", code)
+	}
+	if len(list) != 1 || list[0] != published || published.Status != "Published" {
+		t.Fatalf("outsider sees %+v, want [%+v] with status Published", list, published)
+	}
+}
+
+func TestTenderVersionConflict(t *testing.T) {
+	s := newTestServer(t)
+	_, owner := s.newUser(t, "owner")
+	orgId := s.newOrganization(t, owner)
+
+	var tender tenderOutput
+	body := map[string]string{
+		"name": "tender", "description": "description", "serviceType": "Construction", "organizationId": orgId,
+	}
+	if code := s.do(t, http.MethodPost, "/api/tenders/new", owner, body, &tender); code != http.StatusOK {
+		t.Fatalf("create tender: status %d", code)
+	}
+	editPath := "/api/tenders/" + tender.Id + "/edit?expectedVersion=" + strconv.Itoa(tender.Version)
+	if code := s.do(t, http.MethodPatch, editPath, owner, map[string]string{"name": "edited"}, nil); code != http.StatusOK {
+		t.Fatalf("edit tender: status %d", code)
+	}
+
+	// клиент со старой версией получает 409 и актуальную версию в теле и ETag
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{"status", http.MethodPut, "/api/tenders/" + tender.Id + "/status?status=Published", ""},
+		{"edit", http.MethodPatch, "/api/tenders/" + tender.Id + "/edit", `{"name":"stale"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, s.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("http.NewRequest: %v", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+owner)
+			req.Header.Set("If-Match", strconv.Quote(strconv.Itoa(tender.Version)))
+
+			resp, err := s.Client().Do(req)
+			if err != nil {
+				t.Fatalf("%s %s: %v", tt.method, tt.path, err)
+			}
+			defer resp.Body.Close()
+
+			var conflict struct {
+				CurrentVersion int `json:"currentVersion"`
+			}
+			if err = json.NewDecoder(resp.Body).Decode(&conflict); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			want := tender.Version + 1
+			if resp.StatusCode != http.StatusConflict || conflict.CurrentVersion != want ||
+				resp.Header.Get("ETag") != strconv.Quote(strconv.Itoa(want)) {
+				t.Fatalf("status %d, currentVersion %d, ETag %s, want %d and version %d",
+					resp.StatusCode, conflict.CurrentVersion, resp.Header.Get("ETag"), http.StatusConflict, want)
+			}
+		})
+	}
+}
+
+func TestMetricsNotOnAPIRouter(t *testing.T) {
+	s := newTestServer(t)
+
+	// метрики отдает отдельный сервер на metrics.port, публичный роутер их не обслуживает
+	if code := s.do(t, http.MethodGet, "/metrics", "", nil, nil); code != http.StatusNotFound {
+		t.Fatalf("GET /metrics on API router: status %d, want %d", code, http.StatusNotFound)
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"tender-service/internal/entity"
 	"tender-service/internal/service"
 )
 
@@ -20,16 +23,51 @@ type userRoutes struct {
 	userService service.User
 }
 
-func newUserRoutes(ctx context.Context, log *slog.Logger, route chi.Router, userService service.User) {
+// newUserRoutes изменение профиля и деактивация доступны только самому пользователю (auth)
+func newUserRoutes(
+	ctx context.Context, log *slog.Logger, route chi.Router, userService service.User,
+	auth func(http.Handler) http.Handler,
+) {
 	u := userRoutes{userService: userService}
 	route.Route(
 		userString, func(r chi.Router) {
+			r.Get("/", u.list(ctx, log))
 			r.Post("/create", u.create(ctx, log))
 			r.Get("/{id}", u.get(ctx, log))
+
+			r.Group(
+				func(r chi.Router) {
+					r.Use(auth)
+					r.Patch("/{id}", u.update(ctx, log))
+					r.Post("/{id}/deactivate", u.deactivate(ctx, log))
+				},
+			)
 		},
 	)
 }
 
+type outputUser struct {
+	Id            string     `json:"id"`
+	Username      string     `json:"username"`
+	FirstName     string     `json:"first_name"`
+	LastName      string     `json:"last_name"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+}
+
+func newOutputUser(user entity.User) outputUser {
+	return outputUser{
+		Id:            user.Id,
+		Username:      user.Username,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		DeactivatedAt: user.DeactivatedAt,
+	}
+}
+
 type inputUserCreate struct {
 	Username  string `json:"username"`
 	FirstName string `json:"first_name"`
@@ -99,20 +137,161 @@ func (u *userRoutes) get(ctx context.Context, log *slog.Logger) http.HandlerFunc
 			return
 		}
 
-		type userResp struct {
-			Id        string `json:"id"`
-			Username  string `json:"username"`
-			FirstName string `json:"first_name"`
-			LastName  string `json:"last_name"`
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, newOutputUser(user))
+	}
+}
+
+type inputUserList struct {
+	Limit  int    `validate:"omitempty,number,gte=0,lte=50"`
+	Offset int    `validate:"omitempty,number,gte=0"`
+	Query  string `validate:"omitempty,max=50"`
+}
+
+// list список пользователей с поиском по username, имени и фамилии (?query=)
+func (u *userRoutes) list(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			limit  int
+			offset int
+			err    error
+		)
+		if l := r.URL.Query().Get("limit"); len(l) != 0 {
+			if limit, err = strconv.Atoi(l); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+		if off := r.URL.Query().Get("offset"); len(off) != 0 {
+			if offset, err = strconv.Atoi(off); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+
+		input := inputUserList{
+			Limit:  limit,
+			Offset: offset,
+			Query:  r.URL.Query().Get("query"),
+		}
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
 		}
+
+		users, err := u.userService.GetList(
+			ctx, log, service.UserGetListInput{
+				Limit:  input.Limit,
+				Offset: input.Offset,
+				Query:  input.Query,
+			},
+		)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := make([]outputUser, 0, len(users))
+		for _, v := range users {
+			output = append(output, newOutputUser(v))
+		}
+
 		w.WriteHeader(http.StatusOK)
-		render.JSON(
-			w, r, &userResp{
-				Id:        user.Id,
-				Username:  user.Username,
-				FirstName: user.FirstName,
-				LastName:  user.LastName,
+		render.JSON(w, r, output)
+	}
+}
+
+type inputUserUpdate struct {
+	Id        string `validate:"uuid"`
+	FirstName string `json:"first_name" validate:"omitempty,max=50"`
+	LastName  string `json:"last_name" validate:"omitempty,max=50"`
+}
+
+// update частичное изменение профиля, updated_at обновляется
+func (u *userRoutes) update(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input inputUserUpdate
+		var err error
+
+		if err = render.DecodeJSON(r.Body, &input); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgFailedParsing)
+			return
+		}
+		input.Id = chi.URLParam(r, "id")
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		if done := selfOnly(w, r, log, input.Id); done {
+			return
+		}
+
+		user, err := u.userService.Update(
+			ctx, log, service.UserUpdateInput{
+				Id:        input.Id,
+				FirstName: input.FirstName,
+				LastName:  input.LastName,
 			},
 		)
+		if err != nil {
+			if err == service.ErrUserNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgUserNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, newOutputUser(user))
+	}
+}
+
+// deactivate деактивирует пользователя, его тендеры и предложения сохраняются
+func (u *userRoutes) deactivate(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var err error
+
+		id := chi.URLParam(r, "id")
+		if err = validator.New().Struct(inputUserGet{Id: id}); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		if done := selfOnly(w, r, log, id); done {
+			return
+		}
+
+		if err = u.userService.Deactivate(ctx, log, service.UserDeactivateInput{Id: id}); err != nil {
+			if err == service.ErrUserNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgUserNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		user, err := u.userService.GetById(ctx, log, service.UserGetByIdInput{Id: id})
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, newOutputUser(user))
+	}
+}
+
+// selfOnly пользователь из токена меняет только свой профиль
+func selfOnly(w http.ResponseWriter, r *http.Request, log *slog.Logger, id string) bool {
+	user, done := authenticatedUser(w, r, log, "")
+	if done {
+		return true
+	}
+	if user.Id != id {
+		newErrorResponse(w, r, log, nil, http.StatusForbidden, MsgForbidden)
+		return true
 	}
+	return false
 }
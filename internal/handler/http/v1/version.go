@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/render"
+)
+
+const expectedVersionParam = "expectedVersion"
+
+// parseExpectedVersion версия, которую видел клиент: заголовок If-Match ("3", W/"3" или 3)
+// или параметр expectedVersion. 0 - клиент версию не передал, изменение без проверки
+func parseExpectedVersion(r *http.Request) (int, error) {
+	var fromHeader, fromQuery int
+	var err error
+
+	if h := strings.TrimSpace(r.Header.Get("If-Match")); h != "" {
+		tag := strings.Trim(strings.TrimPrefix(h, "W/"), `"`)
+		if fromHeader, err = strconv.Atoi(tag); err != nil || fromHeader < 1 {
+			return 0, fmt.Errorf("invalid If-Match: %q", h)
+		}
+	}
+	if q := r.URL.Query().Get(expectedVersionParam); q != "" {
+		if fromQuery, err = strconv.Atoi(q); err != nil || fromQuery < 1 {
+			return 0, fmt.Errorf("invalid %s: %q", expectedVersionParam, q)
+		}
+	}
+
+	if fromHeader != 0 && fromQuery != 0 && fromHeader != fromQuery {
+		return 0, fmt.Errorf("If-Match %d and %s %d differ", fromHeader, expectedVersionParam, fromQuery)
+	}
+	return max(fromHeader, fromQuery), nil
+}
+
+// setETag текущая версия сущности для следующего If-Match
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+type outputVersionConflict struct {
+	Reason         string `json:"reason"`
+	CurrentVersion int    `json:"currentVersion"`
+}
+
+// newVersionConflictResponse 409 с текущей версией сущности
+func newVersionConflictResponse(
+	w http.ResponseWriter, r *http.Request, log *slog.Logger, err error, currentVersion int,
+) {
+	log.Error(MsgVersionConflict, slog.Any("error", err), slog.Int("currentVersion", currentVersion))
+	setETag(w, currentVersion)
+	w.WriteHeader(http.StatusConflict)
+	render.JSON(w, r, outputVersionConflict{Reason: MsgVersionConflict, CurrentVersion: currentVersion})
+}
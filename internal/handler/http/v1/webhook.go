@@ -0,0 +1,250 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"tender-service/internal/entity"
+	"tender-service/internal/service"
+)
+
+type inputWebhookCreate struct {
+	Id         string   `validate:"uuid"`
+	Url        string   `json:"url" validate:"required,url,max=1000"`
+	EventTypes []string `json:"eventTypes" validate:"required,min=1,unique,dive,oneof=tender.published tender.closed bid.created bid.approved bid.rejected"`
+}
+
+type outputWebhook struct {
+	Id             string    `json:"id"`
+	OrganizationId string    `json:"organizationId"`
+	Url            string    `json:"url"`
+	EventTypes     []string  `json:"eventTypes"`
+	Secret         string    `json:"secret,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func newOutputWebhook(w entity.Webhook) outputWebhook {
+	return outputWebhook{
+		Id:             w.Id,
+		OrganizationId: w.OrganizationId,
+		Url:            w.Url,
+		EventTypes:     w.EventTypes,
+		CreatedAt:      w.CreatedAt,
+	}
+}
+
+// createWebhook подписывает организацию на события; секрет подписи отдается только в этом ответе
+func (o *orgRoutes) createWebhook(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var input inputWebhookCreate
+		var err error
+
+		if err = render.DecodeJSON(r.Body, &input); err != nil {
+			newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgFailedParsing)
+			return
+		}
+		input.Id = chi.URLParam(r, "id")
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		if _, done := o.manageableOrg(ctx, w, r, log, input.Id); done {
+			return
+		}
+
+		result, err := o.webhookService.Create(
+			ctx, log, service.WebhookCreateInput{
+				OrganizationId: input.Id,
+				Url:            input.Url,
+				EventTypes:     input.EventTypes,
+			},
+		)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := newOutputWebhook(result)
+		output.Secret = result.Secret
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+// webhooks подписки организации
+func (o *orgRoutes) webhooks(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var err error
+
+		id := chi.URLParam(r, "id")
+		if err = validator.New().Struct(inputOrgGet{Id: id}); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		if _, done := o.manageableOrg(ctx, w, r, log, id); done {
+			return
+		}
+
+		result, err := o.webhookService.GetByOrganization(
+			ctx, log, service.WebhookGetByOrganizationInput{OrganizationId: id},
+		)
+		if err != nil {
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := make([]outputWebhook, 0, len(result))
+		for _, v := range result {
+			output = append(output, newOutputWebhook(v))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
+
+type inputWebhookGet struct {
+	Id        string `validate:"uuid"`
+	WebhookId string `validate:"uuid"`
+	Limit     int    `validate:"omitempty,number,gte=0,lte=50"`
+	Offset    int    `validate:"omitempty,number,gte=0"`
+}
+
+// deleteWebhook удаляет подписку вместе с журналом доставок
+func (o *orgRoutes) deleteWebhook(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var err error
+
+		input := inputWebhookGet{
+			Id:        chi.URLParam(r, "id"),
+			WebhookId: chi.URLParam(r, "webhookId"),
+		}
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		if _, done := o.manageableOrg(ctx, w, r, log, input.Id); done {
+			return
+		}
+
+		if err = o.webhookService.Delete(
+			ctx, log, service.WebhookDeleteInput{
+				OrganizationId: input.Id,
+				Id:             input.WebhookId,
+			},
+		); err != nil {
+			if err == service.ErrWebhookNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgWebhookNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, outputWebhook{Id: input.WebhookId, OrganizationId: input.Id})
+	}
+}
+
+type outputWebhookDelivery struct {
+	Id            string          `json:"id"`
+	EventId       string          `json:"eventId"`
+	EventType     string          `json:"eventType"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt *time.Time      `json:"nextAttemptAt,omitempty"`
+	ResponseCode  *int            `json:"responseCode,omitempty"`
+	LastError     *string         `json:"lastError,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	DeliveredAt   *time.Time      `json:"deliveredAt,omitempty"`
+}
+
+// webhookDeliveries журнал доставок подписки, новые первыми
+func (o *orgRoutes) webhookDeliveries(ctx context.Context, log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var (
+			limit  int
+			offset int
+			err    error
+		)
+		if l := r.URL.Query().Get("limit"); len(l) != 0 {
+			if limit, err = strconv.Atoi(l); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+		if off := r.URL.Query().Get("offset"); len(off) != 0 {
+			if offset, err = strconv.Atoi(off); err != nil {
+				newErrorResponse(w, r, log, err, http.StatusBadRequest, MsgInvalidReq)
+				return
+			}
+		}
+
+		input := inputWebhookGet{
+			Id:        chi.URLParam(r, "id"),
+			WebhookId: chi.URLParam(r, "webhookId"),
+			Limit:     limit,
+			Offset:    offset,
+		}
+		if err = validator.New().Struct(input); err != nil {
+			newErrorValidateResponse(w, r, log, http.StatusBadRequest, MsgInvalidReq, err)
+			return
+		}
+
+		if _, done := o.manageableOrg(ctx, w, r, log, input.Id); done {
+			return
+		}
+
+		result, err := o.webhookService.GetDeliveries(
+			ctx, log, service.WebhookGetDeliveriesInput{
+				OrganizationId: input.Id,
+				Id:             input.WebhookId,
+				Limit:          input.Limit,
+				Offset:         input.Offset,
+			},
+		)
+		if err != nil {
+			if err == service.ErrWebhookNotFound {
+				newErrorResponse(w, r, log, err, http.StatusNotFound, MsgWebhookNotFound)
+				return
+			}
+			newErrorResponse(w, r, log, err, http.StatusInternalServerError, MsgInternalServerErr)
+			return
+		}
+
+		output := make([]outputWebhookDelivery, 0, len(result))
+		for _, v := range result {
+			d := outputWebhookDelivery{
+				Id:           v.Id,
+				EventId:      v.EventId,
+				EventType:    v.EventType,
+				Payload:      v.Payload,
+				Status:       v.Status,
+				Attempts:     v.Attempts,
+				ResponseCode: v.ResponseCode,
+				LastError:    v.LastError,
+				CreatedAt:    v.CreatedAt,
+				DeliveredAt:  v.DeliveredAt,
+			}
+			if v.Status == entity.WebhookDeliveryPending {
+				d.NextAttemptAt = &v.NextAttemptAt
+			}
+			output = append(output, d)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		render.JSON(w, r, output)
+	}
+}
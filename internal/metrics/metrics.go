@@ -0,0 +1,108 @@
+// Package metrics собирает метрики сервиса и отдает их в текстовом формате Prometheus.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tender_service"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+
+	tendersCreated   prometheus.Counter
+	tendersPublished prometheus.Counter
+	tendersClosed    prometheus.Counter
+	bidsCreated      prometheus.Counter
+	bidDecisions     *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Количество HTTP-запросов по маршрутам и кодам ответа.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Время обработки HTTP-запросов по маршрутам.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		tendersCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tenders_created_total",
+			Help:      "Количество созданных тендеров.",
+		}),
+		tendersPublished: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tenders_published_total",
+			Help:      "Количество опубликованных тендеров.",
+		}),
+		tendersClosed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tenders_closed_total",
+			Help:      "Количество закрытых тендеров, в том числе по кворуму одобрений.",
+		}),
+		bidsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bids_created_total",
+			Help:      "Количество созданных предложений.",
+		}),
+		bidDecisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bid_decisions_total",
+			Help:      "Количество решений по предложениям по результату.",
+		}, []string{"decision"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests, m.requestDuration,
+		m.tendersCreated, m.tendersPublished, m.tendersClosed, m.bidsCreated, m.bidDecisions,
+	)
+	return m
+}
+
+// Handler отдает метрики в текстовом формате Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveRequest учитывает обработанный запрос; route - шаблон маршрута chi, а не путь
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	m.requests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.requestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func (m *Metrics) TenderCreated() {
+	m.tendersCreated.Inc()
+}
+
+func (m *Metrics) TenderPublished() {
+	m.tendersPublished.Inc()
+}
+
+func (m *Metrics) TenderClosed() {
+	m.tendersClosed.Inc()
+}
+
+func (m *Metrics) BidCreated() {
+	m.bidsCreated.Inc()
+}
+
+func (m *Metrics) BidDecision(decision string) {
+	m.bidDecisions.WithLabelValues(decision).Inc()
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStater пул соединений, статистика которого снимается в момент опроса
+type PoolStater interface {
+	Stat() *pgxpool.Stat
+}
+
+type poolCollector struct {
+	pool PoolStater
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	newConnsCount        *prometheus.Desc
+}
+
+// RegisterPool добавляет метрики пула соединений с базой данных
+func (m *Metrics) RegisterPool(pool PoolStater) {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+	m.registry.MustRegister(&poolCollector{
+		pool:                 pool,
+		acquiredConns:        desc("acquired_conns", "Количество занятых соединений."),
+		idleConns:            desc("idle_conns", "Количество свободных соединений."),
+		constructingConns:    desc("constructing_conns", "Количество устанавливаемых соединений."),
+		totalConns:           desc("total_conns", "Общее количество соединений."),
+		maxConns:             desc("max_conns", "Максимальный размер пула."),
+		acquireCount:         desc("acquire_total", "Количество успешных получений соединения."),
+		acquireDuration:      desc("acquire_duration_seconds_total", "Суммарное время ожидания соединения."),
+		canceledAcquireCount: desc("canceled_acquire_total", "Количество получений соединения, отмененных контекстом."),
+		emptyAcquireCount:    desc("empty_acquire_total", "Количество получений соединения с ожиданием из-за пустого пула."),
+		newConnsCount:        desc("new_conns_total", "Количество открытых соединений."),
+	})
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.canceledAcquireCount
+	ch <- c.emptyAcquireCount
+	ch <- c.newConnsCount
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(
+		c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds(),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()),
+	)
+	ch <- prometheus.MustNewConstMetric(c.newConnsCount, prometheus.CounterValue, float64(stat.NewConnsCount()))
+}
@@ -0,0 +1,98 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"tender-service/internal/repo"
+)
+
+const (
+	defaultInterval    = time.Second
+	defaultBatchSize   = 100
+	defaultMaxAttempts = 10
+)
+
+// Dispatcher периодически вычитывает outbox и передает события в EventPublisher
+type Dispatcher struct {
+	outbox      repo.Outbox
+	publisher   EventPublisher
+	log         *slog.Logger
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+}
+
+type Option func(*Dispatcher)
+
+func Interval(interval time.Duration) Option {
+	return func(d *Dispatcher) {
+		if interval > 0 {
+			d.interval = interval
+		}
+	}
+}
+
+func BatchSize(size int) Option {
+	return func(d *Dispatcher) {
+		if size > 0 {
+			d.batchSize = size
+		}
+	}
+}
+
+// MaxAttempts после стольких неудачных попыток событие больше не доставляется
+func MaxAttempts(attempts int) Option {
+	return func(d *Dispatcher) {
+		if attempts > 0 {
+			d.maxAttempts = attempts
+		}
+	}
+}
+
+func NewDispatcher(outbox repo.Outbox, publisher EventPublisher, log *slog.Logger, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		outbox:      outbox,
+		publisher:   publisher,
+		log:         log,
+		interval:    defaultInterval,
+		batchSize:   defaultBatchSize,
+		maxAttempts: defaultMaxAttempts,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Run доставляет события до отмены ctx. Полная пачка вычитывается повторно без ожидания.
+func (d *Dispatcher) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := d.DispatchOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.log.Error(fmt.Sprintf("outbox - Dispatcher - Run: %v", err))
+		}
+
+		if n == d.batchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(d.interval)
+		}
+	}
+}
+
+// DispatchOnce обрабатывает одну пачку событий и возвращает число доставленных
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	return d.outbox.Dispatch(ctx, d.batchSize, d.maxAttempts, d.publisher.Publish)
+}
@@ -0,0 +1,130 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/outbox"
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/memory"
+)
+
+var errPublish = errors.New("publish failed")
+
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(context.Context, entity.Event) error {
+	return errPublish
+}
+
+// publishTenders создает и публикует n тендеров, каждая публикация пишет в outbox событие tender.published
+func publishTenders(t *testing.T, repos *repo.Repositories, n int) {
+	t.Helper()
+	ctx := context.Background()
+
+	const creator = "creator"
+	if _, err := repos.User.Create(ctx, entity.User{Username: creator}); err != nil {
+		t.Fatalf("User.Create: %v", err)
+	}
+	org, err := repos.Organization.Create(ctx, entity.Organization{Name: "org", OrganizationType: "LLC"})
+	if err != nil {
+		t.Fatalf("Organization.Create: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		tender, err := repos.Tender.Create(ctx, entity.Tender{
+			Name:            fmt.Sprintf("tender %d", i),
+			Description:     "description",
+			ServiceType:     "Construction",
+			OrganizationId:  org.Id,
+			CreatorUsername: creator,
+		})
+		if err != nil {
+			t.Fatalf("Tender.Create: %v", err)
+		}
+		if err = repos.Tender.PutStatus(ctx, tender.Id, "Published"); err != nil {
+			t.Fatalf("Tender.PutStatus: %v", err)
+		}
+	}
+}
+
+func discardLog() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestDispatcherFailingPublisher(t *testing.T) {
+	const maxAttempts = 3
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+	publishTenders(t, repos, 1)
+
+	// MemoryPublisher стоит перед падающим издателем и видит каждую попытку доставки
+	attempts := outbox.NewMemoryPublisher()
+	d := outbox.NewDispatcher(
+		repos.Outbox, outbox.NewMultiPublisher(attempts, failingPublisher{}), discardLog(),
+		outbox.MaxAttempts(maxAttempts),
+	)
+
+	for i := 1; i <= maxAttempts+1; i++ {
+		n, err := d.DispatchOnce(ctx)
+		if err != nil {
+			t.Fatalf("DispatchOnce: %v", err)
+		}
+		if n != 0 {
+			t.Fatalf("DispatchOnce delivered %d events with a failing publisher", n)
+		}
+	}
+	// каждая неудача увеличивает attempts, после maxAttempts событие больше не выбирается
+	if got := len(attempts.Events()); got != maxAttempts {
+		t.Fatalf("event was attempted %d times, want %d", got, maxAttempts)
+	}
+
+	delivered := outbox.NewMemoryPublisher()
+	n, err := outbox.NewDispatcher(
+		repos.Outbox, delivered, discardLog(), outbox.MaxAttempts(maxAttempts),
+	).DispatchOnce(ctx)
+	if err != nil || n != 0 || len(delivered.Events()) != 0 {
+		t.Fatalf("exhausted event was dispatched again: n=%d err=%v", n, err)
+	}
+}
+
+func TestDispatcherRereadsFullBatch(t *testing.T) {
+	const events = 3
+	repos := memory.NewRepositories()
+	publishTenders(t, repos, events)
+
+	publisher := outbox.NewMemoryPublisher()
+	// интервал больше времени теста: все события доставляются только повторным чтением полных пачек
+	d := outbox.NewDispatcher(
+		repos.Outbox, publisher, discardLog(), outbox.BatchSize(1), outbox.Interval(time.Hour),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(publisher.Events()) < events {
+		if time.Now().After(deadline) {
+			t.Fatalf("delivered %d of %d events", len(publisher.Events()), events)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for _, e := range publisher.Events() {
+		if e.Type != entity.EventTenderPublished {
+			t.Fatalf("delivered event of type %s, want %s", e.Type, entity.EventTenderPublished)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"tender-service/internal/entity"
+)
+
+// EventPublisher доставляет доменные события во внешний мир.
+// Ошибка оставляет событие в outbox для повторной попытки.
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.Event) error
+}
+
+// LogPublisher пишет события в лог
+type LogPublisher struct {
+	log *slog.Logger
+}
+
+func NewLogPublisher(log *slog.Logger) *LogPublisher {
+	return &LogPublisher{log: log}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, event entity.Event) error {
+	p.log.Info("outbox - event published",
+		slog.String("id", event.Id),
+		slog.String("type", string(event.Type)),
+		slog.String("aggregateId", event.AggregateId),
+		slog.String("payload", string(event.Payload)),
+	)
+	return nil
+}
+
+// MemoryPublisher накапливает события в памяти, используется в тестах
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []entity.Event
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, event entity.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Events возвращает копию полученных событий
+func (p *MemoryPublisher) Events() []entity.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]entity.Event(nil), p.events...)
+}
+
+// Reset очищает полученные события
+func (p *MemoryPublisher) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = nil
+}
+
+// MultiPublisher передает событие всем издателям по очереди, первая ошибка прерывает доставку
+type MultiPublisher struct {
+	publishers []EventPublisher
+}
+
+func NewMultiPublisher(publishers ...EventPublisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+func (p *MultiPublisher) Publish(ctx context.Context, event entity.Event) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"tender-service/internal/entity"
+)
+
+type AuditRepo struct {
+	*Store
+}
+
+func NewAuditRepo(store *Store) *AuditRepo {
+	return &AuditRepo{store}
+}
+
+// entityOrganization организация, которой принадлежит сущность: для предложения - организация тендера
+func (d *data) entityOrganization(entityType, entityId string) *string {
+	tenderId := entityId
+	switch entityType {
+	case entity.AuditEntityTender:
+	case entity.AuditEntityBid:
+		b, ok := d.bids[entityId]
+		if !ok {
+			return nil
+		}
+		tenderId = b.TenderId
+	default:
+		return nil
+	}
+
+	t, ok := d.tenders[tenderId]
+	if !ok {
+		return nil
+	}
+	return &t.OrganizationId
+}
+
+func (r *AuditRepo) Create(ctx context.Context, input entity.AuditEntry) error {
+	defer r.lock(ctx)()
+
+	input.Id = newId()
+	input.OrganizationId = r.data.entityOrganization(input.EntityType, input.EntityId)
+	input.CreatedAt = now()
+
+	r.data.audit = append(r.data.audit, input)
+	return nil
+}
+
+// GetByEntity журнал сущности, новые записи первыми. Возвращаются только записи организаций,
+// за которые отвечает userId
+func (r *AuditRepo) GetByEntity(ctx context.Context, entityId, userId string, limit, offset int) (
+	[]entity.AuditEntry, error,
+) {
+	defer r.lock(ctx)()
+
+	orgs := r.data.responsibleOrgs(userId)
+
+	var output []entity.AuditEntry
+	for _, e := range r.data.audit {
+		if e.EntityId == entityId && e.OrganizationId != nil && orgs[*e.OrganizationId] {
+			output = append(output, e)
+		}
+	}
+	slices.SortFunc(output, func(a, b entity.AuditEntry) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	return offsetLimit(output, limit, offset), nil
+}
@@ -0,0 +1,280 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+)
+
+type BidRepo struct {
+	*Store
+}
+
+func NewBidRepo(store *Store) *BidRepo {
+	return &BidRepo{store}
+}
+
+// authoredBy автор предложения - пользователь userId лично или организация, за которую он отвечает
+func authoredBy(b entity.Bid, userId string, orgs map[string]bool) bool {
+	switch b.AuthorType {
+	case entity.BidAuthorTypeUser:
+		return b.AuthorId == userId
+	case entity.BidAuthorTypeOrganization:
+		return orgs[b.AuthorId]
+	}
+	return false
+}
+
+// bidVisibleTo автор (или ответственный за организацию-автора) видит свои предложения в любом статусе,
+// ответственные за организацию тендера - все предложения кроме черновиков и отмененных
+func (d *data) bidVisibleTo(b entity.Bid, userId string, orgs map[string]bool) bool {
+	if authoredBy(b, userId, orgs) {
+		return true
+	}
+	return b.Status != bidStatusCreated && b.Status != bidStatusCanceled && orgs[d.tenders[b.TenderId].OrganizationId]
+}
+
+func (r *BidRepo) Create(ctx context.Context, input entity.Bid) (entity.Bid, error) {
+	defer r.lock(ctx)()
+
+	if _, ok := r.data.tenders[input.TenderId]; !ok {
+		return entity.Bid{}, fmt.Errorf("BidRepo - Create - tender: %w", errForeignKey)
+	}
+	switch input.AuthorType {
+	case entity.BidAuthorTypeUser:
+		if _, ok := r.data.users[input.AuthorId]; !ok {
+			return entity.Bid{}, fmt.Errorf("BidRepo - Create - author user: %w", errForeignKey)
+		}
+	case entity.BidAuthorTypeOrganization:
+		if _, ok := r.data.organizations[input.AuthorId]; !ok {
+			return entity.Bid{}, fmt.Errorf("BidRepo - Create - author organization: %w", errForeignKey)
+		}
+	default:
+		return entity.Bid{}, fmt.Errorf("BidRepo - Create - unknown author type %q", input.AuthorType)
+	}
+
+	output := entity.Bid{
+		Id:          newId(),
+		Name:        input.Name,
+		Description: input.Description,
+		Status:      bidStatusCreated,
+		TenderId:    input.TenderId,
+		AuthorType:  input.AuthorType,
+		AuthorId:    input.AuthorId,
+		Version:     1,
+		CreatedAt:   now(),
+	}
+	r.data.bids[output.Id] = output
+	r.data.saveBidHistory(output.Id)
+
+	err := r.data.saveEvent(entity.EventBidCreated, output.Id, entity.BidCreatedPayload{
+		BidId:      output.Id,
+		TenderId:   output.TenderId,
+		AuthorType: output.AuthorType,
+		AuthorId:   output.AuthorId,
+	})
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepo - Create - saveEvent: %w", err)
+	}
+	return output, nil
+}
+
+func (r *BidRepo) GetById(ctx context.Context, bidId string) (entity.Bid, error) {
+	defer r.lock(ctx)()
+
+	output, ok := r.data.bids[bidId]
+	if !ok {
+		return entity.Bid{}, repoerrs.ErrNotFound
+	}
+	return output, nil
+}
+
+// ExistsByAuthor есть ли у автора authorId предложение на тендер tenderId
+func (r *BidRepo) ExistsByAuthor(ctx context.Context, tenderId, authorId string) (bool, error) {
+	defer r.lock(ctx)()
+
+	for _, b := range r.data.bids {
+		if b.TenderId == tenderId && b.AuthorId == authorId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetMyPagination предложения, автором которых является пользователь authorId
+// или организации, за которые он отвечает
+func (r *BidRepo) GetMyPagination(ctx context.Context, page entity.Pagination, authorId string) (
+	entity.Page[entity.Bid], error,
+) {
+	defer r.lock(ctx)()
+
+	orgs := r.data.responsibleOrgs(authorId)
+
+	var output []entity.Bid
+	for _, b := range r.data.bids {
+		if authoredBy(b, authorId, orgs) {
+			output = append(output, b)
+		}
+	}
+
+	result, err := keyset(output, page, bidOrder, bidCursor)
+	if err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("BidRepo - GetMyPagination - keyset: %w", err)
+	}
+	return result, nil
+}
+
+// GetByTenderID предложения тендера, видимые пользователю userId
+func (r *BidRepo) GetByTenderID(ctx context.Context, page entity.Pagination, userId, tenderId string) (
+	entity.Page[entity.Bid], error,
+) {
+	defer r.lock(ctx)()
+
+	orgs := r.data.responsibleOrgs(userId)
+
+	var output []entity.Bid
+	for _, b := range r.data.bids {
+		if b.TenderId == tenderId && r.data.bidVisibleTo(b, userId, orgs) {
+			output = append(output, b)
+		}
+	}
+
+	result, err := keyset(output, page, bidOrder, bidCursor)
+	if err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("BidRepo - GetByTenderID - keyset: %w", err)
+	}
+	return result, nil
+}
+
+// Search видимые пользователю предложения, найденные по названию и описанию, по убыванию релевантности
+func (r *BidRepo) Search(ctx context.Context, page entity.Pagination, language, q, userId string) (
+	[]entity.Bid, error,
+) {
+	defer r.lock(ctx)()
+
+	orgs := r.data.responsibleOrgs(userId)
+
+	var visible []entity.Bid
+	for _, b := range r.data.bids {
+		if r.data.bidVisibleTo(b, userId, orgs) {
+			visible = append(visible, b)
+		}
+	}
+
+	output, err := fullText(visible, language, q, func(b entity.Bid) (string, string, string) {
+		return b.Id, b.Name, b.Description
+	})
+	if err != nil {
+		return nil, fmt.Errorf("BidRepo - Search - fullText: %w", err)
+	}
+	return offsetLimit(output, page.Limit, page.Offset), nil
+}
+
+// PutStatus меняет статус без изменения версии; отсутствующее предложение не считается ошибкой, как и в pgdb
+func (r *BidRepo) PutStatus(ctx context.Context, bidId, status string) error {
+	defer r.lock(ctx)()
+
+	b, ok := r.data.bids[bidId]
+	if !ok {
+		return nil
+	}
+	b.Status = status
+	r.data.bids[bidId] = b
+	return nil
+}
+
+// EditBid меняет непустые поля; отсутствующее предложение не считается ошибкой, как и в pgdb
+func (r *BidRepo) EditBid(ctx context.Context, input entity.Bid, bidId string) error {
+	defer r.lock(ctx)()
+
+	b, ok := r.data.bids[bidId]
+	if !ok {
+		return nil
+	}
+
+	if input.Name != "" {
+		b.Name = input.Name
+	}
+	if input.Description != "" {
+		b.Description = input.Description
+	}
+	r.data.bids[bidId] = b
+	return nil
+}
+
+// IncrementVersion увеличивает версию и сохраняет снимок в историю. При expectedVersion > 0
+// текущая версия должна с ней совпасть, иначе *repoerrs.VersionConflictError
+func (r *BidRepo) IncrementVersion(ctx context.Context, bidId string, expectedVersion int) error {
+	defer r.lock(ctx)()
+
+	b, ok := r.data.bids[bidId]
+	if !ok {
+		return repoerrs.ErrNotFound
+	}
+	if expectedVersion > 0 && b.Version != expectedVersion {
+		return &repoerrs.VersionConflictError{CurrentVersion: b.Version}
+	}
+
+	b.Version++
+	r.data.bids[bidId] = b
+	r.data.saveBidHistory(bidId)
+	return nil
+}
+
+// setBidStatus меняет статус предложения, инкрементирует версию и сохраняет снимок
+func (d *data) setBidStatus(bidId, status string) {
+	b := d.bids[bidId]
+	b.Status = status
+	b.Version++
+	d.bids[bidId] = b
+
+	d.saveBidHistory(bidId)
+}
+
+// saveBidHistory сохраняет снимок текущего состояния предложения
+func (d *data) saveBidHistory(bidId string) {
+	d.bidHistory[bidId] = append(d.bidHistory[bidId], d.bids[bidId])
+}
+
+func (r *BidRepo) GetVersion(ctx context.Context, bidId string, version int) (entity.Bid, error) {
+	defer r.lock(ctx)()
+
+	return r.data.bidVersion(bidId, version)
+}
+
+func (d *data) bidVersion(bidId string, version int) (entity.Bid, error) {
+	for _, b := range d.bidHistory[bidId] {
+		if b.Version == version {
+			return b, nil
+		}
+	}
+	return entity.Bid{}, repoerrs.ErrNotFound
+}
+
+func (r *BidRepo) GetVersions(ctx context.Context, bidId string) ([]entity.Bid, error) {
+	defer r.lock(ctx)()
+
+	return slices.Clone(r.data.bidHistory[bidId]), nil
+}
+
+// Rollback восстанавливает название и описание предложения из снимка version как новую версию
+func (r *BidRepo) Rollback(ctx context.Context, bidId string, version int) error {
+	defer r.lock(ctx)()
+
+	snapshot, err := r.data.bidVersion(bidId, version)
+	if err != nil {
+		return err
+	}
+
+	b := r.data.bids[bidId]
+	b.Name = snapshot.Name
+	b.Description = snapshot.Description
+	b.Version++
+	r.data.bids[bidId] = b
+
+	r.data.saveBidHistory(bidId)
+	return nil
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+)
+
+type BidDecisionRepo struct {
+	*Store
+}
+
+func NewBidDecisionRepo(store *Store) *BidDecisionRepo {
+	return &BidDecisionRepo{store}
+}
+
+// Submit сохраняет решение ответственного и сразу применяет его:
+// одно отклонение отклоняет предложение, набор кворума одобряет предложение и закрывает тендер.
+// Кворум равен min(quorumLimit, количество ответственных за организацию тендера).
+func (r *BidDecisionRepo) Submit(ctx context.Context, input entity.BidDecision, quorumLimit int) error {
+	defer r.lock(ctx)()
+
+	b, ok := r.data.bids[input.BidId]
+	if !ok {
+		return repoerrs.ErrNotFound
+	}
+	t := r.data.tenders[b.TenderId]
+	if b.Status != bidStatusPublished || t.Status != tenderStatusPublished {
+		return repoerrs.ErrInvalidStatus
+	}
+
+	if _, ok = r.data.users[input.UserId]; !ok {
+		return fmt.Errorf("BidDecisionRepo.Submit - user: %w", errForeignKey)
+	}
+	for _, d := range r.data.decisions {
+		if d.BidId == input.BidId && d.UserId == input.UserId {
+			return repoerrs.ErrAlreadyExists
+		}
+	}
+
+	// при ошибке ниже решение не должно остаться сохраненным
+	snapshot := r.data.clone()
+	if err := r.submit(input, quorumLimit, t); err != nil {
+		r.data = snapshot
+		return err
+	}
+	return nil
+}
+
+func (r *BidDecisionRepo) submit(input entity.BidDecision, quorumLimit int, t entity.Tender) error {
+	r.data.decisions = append(r.data.decisions, entity.BidDecision{
+		Id:        newId(),
+		BidId:     input.BidId,
+		UserId:    input.UserId,
+		Decision:  input.Decision,
+		CreatedAt: now(),
+	})
+
+	switch input.Decision {
+	case bidDecisionRejected:
+		r.data.setBidStatus(input.BidId, bidStatusRejected)
+		err := r.data.saveEvent(entity.EventBidRejected, input.BidId, entity.BidDecidedPayload{
+			BidId:    input.BidId,
+			TenderId: t.Id,
+			Decision: bidDecisionRejected,
+		})
+		if err != nil {
+			return fmt.Errorf("BidDecisionRepo.Submit - saveEvent: %w", err)
+		}
+	case bidDecisionApproved:
+		var approvals, responsibles int
+		for _, d := range r.data.decisions {
+			if d.BidId == input.BidId && d.Decision == bidDecisionApproved {
+				approvals++
+			}
+		}
+		for _, o := range r.data.responsibles {
+			if o.OrganizationId == t.OrganizationId {
+				responsibles++
+			}
+		}
+
+		if approvals >= min(quorumLimit, responsibles) {
+			r.data.setBidStatus(input.BidId, bidStatusApproved)
+			err := r.data.saveEvent(entity.EventBidApproved, input.BidId, entity.BidDecidedPayload{
+				BidId:    input.BidId,
+				TenderId: t.Id,
+				Decision: bidDecisionApproved,
+			})
+			if err != nil {
+				return fmt.Errorf("BidDecisionRepo.Submit - saveEvent: %w", err)
+			}
+			if err = r.data.setTenderStatus(t.Id, tenderStatusClosed); err != nil {
+				return fmt.Errorf("BidDecisionRepo.Submit - setTenderStatus: %w", err)
+			}
+		}
+	}
+	return nil
+}
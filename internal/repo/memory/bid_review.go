@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"tender-service/internal/entity"
+)
+
+type BidReviewRepo struct {
+	*Store
+}
+
+func NewBidReviewRepo(store *Store) *BidReviewRepo {
+	return &BidReviewRepo{store}
+}
+
+func (r *BidReviewRepo) Create(ctx context.Context, input entity.BidReview) (entity.BidReview, error) {
+	defer r.lock(ctx)()
+
+	if _, ok := r.data.bids[input.BidId]; !ok {
+		return entity.BidReview{}, fmt.Errorf("BidReviewRepo - Create - bid: %w", errForeignKey)
+	}
+
+	output := entity.BidReview{
+		Id:             newId(),
+		Description:    input.Description,
+		BidId:          input.BidId,
+		CreatedAt:      now(),
+		UserId:         input.UserId,
+		OrganizationId: input.OrganizationId,
+	}
+	r.data.reviews = append(r.data.reviews, output)
+	return output, nil
+}
+
+// GetByAuthorPagination отзывы на предложения автора по всем тендерам,
+// organizationId опционально ограничивает отзывы одной организацией
+func (r *BidReviewRepo) GetByAuthorPagination(
+	ctx context.Context, limit, offset int, authorId, organizationId string,
+) ([]entity.BidReview, error) {
+	defer r.lock(ctx)()
+
+	var output []entity.BidReview
+	for _, review := range r.data.reviews {
+		if r.data.bids[review.BidId].AuthorId != authorId {
+			continue
+		}
+		if organizationId != "" && review.OrganizationId != organizationId {
+			continue
+		}
+		output = append(output, review)
+	}
+	slices.SortStableFunc(output, func(a, b entity.BidReview) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return offsetLimit(output, limit, offset), nil
+}
@@ -0,0 +1,13 @@
+package memory_test
+
+import (
+	"testing"
+
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/memory"
+	"tender-service/internal/repo/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) *repo.Repositories { return memory.NewRepositories() })
+}
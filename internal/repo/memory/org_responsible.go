@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+)
+
+type OrgResponsibleRepo struct {
+	*Store
+}
+
+func NewOrgResponsibleRepo(store *Store) *OrgResponsibleRepo {
+	return &OrgResponsibleRepo{store}
+}
+
+func (r *OrgResponsibleRepo) Create(
+	ctx context.Context, input entity.OrgResponsible,
+) (entity.OrgResponsible, error) {
+	defer r.lock(ctx)()
+
+	if _, ok := r.data.organizations[input.OrganizationId]; !ok {
+		return entity.OrgResponsible{}, fmt.Errorf("OrgResponsibleRepo - Create - organization: %w", errForeignKey)
+	}
+	if _, ok := r.data.users[input.UserId]; !ok {
+		return entity.OrgResponsible{}, fmt.Errorf("OrgResponsibleRepo - Create - user: %w", errForeignKey)
+	}
+
+	for _, o := range r.data.responsibles {
+		if o.UserId != input.UserId {
+			continue
+		}
+		if o.OrganizationId == input.OrganizationId {
+			return entity.OrgResponsible{}, repoerrs.ErrAlreadyExists
+		}
+		// пользователь уже ответственный в другой организации
+		return entity.OrgResponsible{}, repoerrs.ErrConflict
+	}
+
+	output := entity.OrgResponsible{
+		Id:             newId(),
+		OrganizationId: input.OrganizationId,
+		UserId:         input.UserId,
+	}
+	r.data.responsibles[output.Id] = output
+	return output, nil
+}
+
+func (r *OrgResponsibleRepo) GetById(ctx context.Context, id string) (entity.OrgResponsible, error) {
+	defer r.lock(ctx)()
+
+	output, ok := r.data.responsibles[id]
+	if !ok {
+		return entity.OrgResponsible{}, repoerrs.ErrNotFound
+	}
+	return output, nil
+}
+
+// GetByIds ответственный за неудаленную организацию; за удаленную - ErrNotFound
+func (r *OrgResponsibleRepo) GetByIds(ctx context.Context, input entity.OrgResponsible) (
+	entity.OrgResponsible, error,
+) {
+	defer r.lock(ctx)()
+
+	if org, ok := r.data.organizations[input.OrganizationId]; !ok || org.DeletedAt != nil {
+		return entity.OrgResponsible{}, repoerrs.ErrNotFound
+	}
+	for _, o := range r.data.responsibles {
+		if o.OrganizationId == input.OrganizationId && o.UserId == input.UserId {
+			return o, nil
+		}
+	}
+	return entity.OrgResponsible{}, repoerrs.ErrNotFound
+}
+
+// GetByOrganizationPagination ответственные за организацию
+func (r *OrgResponsibleRepo) GetByOrganizationPagination(
+	ctx context.Context, limit, offset int, organizationId string,
+) ([]entity.OrgResponsible, error) {
+	defer r.lock(ctx)()
+
+	var output []entity.OrgResponsible
+	for _, o := range r.data.responsibles {
+		if o.OrganizationId == organizationId {
+			output = append(output, o)
+		}
+	}
+	slices.SortFunc(output, func(a, b entity.OrgResponsible) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	return offsetLimit(output, limit, offset), nil
+}
+
+// Delete снимает с пользователя ответственность за организацию
+func (r *OrgResponsibleRepo) Delete(ctx context.Context, input entity.OrgResponsible) error {
+	defer r.lock(ctx)()
+
+	for id, o := range r.data.responsibles {
+		if o.OrganizationId == input.OrganizationId && o.UserId == input.UserId {
+			delete(r.data.responsibles, id)
+			return nil
+		}
+	}
+	return repoerrs.ErrNotFound
+}
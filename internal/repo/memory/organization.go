@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+)
+
+type OrganizationRepo struct {
+	*Store
+}
+
+func NewOrganizationRepo(store *Store) *OrganizationRepo {
+	return &OrganizationRepo{store}
+}
+
+func (r *OrganizationRepo) Create(ctx context.Context, input entity.Organization) (entity.Organization, error) {
+	defer r.lock(ctx)()
+
+	t := now()
+	output := entity.Organization{
+		Id:               newId(),
+		Name:             input.Name,
+		Description:      input.Description,
+		OrganizationType: input.OrganizationType,
+		CreatedAt:        t,
+		UpdatedAt:        t,
+	}
+	r.data.organizations[output.Id] = output
+	return output, nil
+}
+
+// GetById удаленные организации не возвращаются
+func (r *OrganizationRepo) GetById(ctx context.Context, id string) (entity.Organization, error) {
+	defer r.lock(ctx)()
+
+	output, ok := r.data.organizations[id]
+	if !ok || output.DeletedAt != nil {
+		return entity.Organization{}, repoerrs.ErrNotFound
+	}
+	return output, nil
+}
+
+// GetPagination список неудаленных организаций, name - поиск по подстроке названия без учета регистра
+func (r *OrganizationRepo) GetPagination(ctx context.Context, limit, offset int, name string) (
+	[]entity.Organization, error,
+) {
+	defer r.lock(ctx)()
+
+	name = strings.ToLower(name)
+
+	var output []entity.Organization
+	for _, o := range r.data.organizations {
+		if o.DeletedAt != nil || !strings.Contains(strings.ToLower(o.Name), name) {
+			continue
+		}
+		output = append(output, o)
+	}
+	slices.SortFunc(output, func(a, b entity.Organization) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	return offsetLimit(output, limit, offset), nil
+}
+
+// Update меняет непустые поля организации и обновляет updated_at
+func (r *OrganizationRepo) Update(ctx context.Context, id string, input entity.Organization) (
+	entity.Organization, error,
+) {
+	defer r.lock(ctx)()
+
+	output, ok := r.data.organizations[id]
+	if !ok || output.DeletedAt != nil {
+		return entity.Organization{}, repoerrs.ErrNotFound
+	}
+
+	if input.Name != "" {
+		output.Name = input.Name
+	}
+	if input.Description != "" {
+		output.Description = input.Description
+	}
+	if input.OrganizationType != "" {
+		output.OrganizationType = input.OrganizationType
+	}
+	output.UpdatedAt = now()
+
+	r.data.organizations[id] = output
+	return output, nil
+}
+
+// Delete мягкое удаление: тендеры и ответственные организации остаются в хранилище
+func (r *OrganizationRepo) Delete(ctx context.Context, id string) error {
+	defer r.lock(ctx)()
+
+	output, ok := r.data.organizations[id]
+	if !ok || output.DeletedAt != nil {
+		return repoerrs.ErrNotFound
+	}
+
+	t := now()
+	output.DeletedAt = &t
+	output.UpdatedAt = t
+
+	r.data.organizations[id] = output
+	return nil
+}
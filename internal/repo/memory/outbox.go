@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"tender-service/internal/entity"
+)
+
+// outboxEvent событие outbox с состоянием доставки
+type outboxEvent struct {
+	entity.Event
+	Published bool
+	LastError string
+}
+
+type OutboxRepo struct {
+	*Store
+}
+
+func NewOutboxRepo(store *Store) *OutboxRepo {
+	return &OutboxRepo{store}
+}
+
+// saveEvent записывает событие в outbox вместе с изменением состояния; вызывается под блокировкой хранилища
+func (d *data) saveEvent(eventType entity.EventType, aggregateId string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("saveEvent - json.Marshal: %w", err)
+	}
+
+	d.outbox = append(d.outbox, outboxEvent{
+		Event: entity.Event{
+			Id:          newId(),
+			Type:        eventType,
+			AggregateId: aggregateId,
+			Payload:     body,
+			CreatedAt:   now(),
+		},
+	})
+	return nil
+}
+
+// Dispatch передает пачку неотправленных событий в publish и отмечает отправленные; при ошибке увеличивает attempts.
+// publish вызывается без блокировки хранилища, поэтому может обращаться к другим репозиториям.
+// Возвращает число отправленных событий.
+func (r *OutboxRepo) Dispatch(
+	ctx context.Context, limit, maxAttempts int, publish func(context.Context, entity.Event) error,
+) (int, error) {
+	r.dispatchMu.Lock()
+	defer r.dispatchMu.Unlock()
+
+	unlock := r.lock(ctx)
+	var events []entity.Event
+	for _, e := range r.data.outbox {
+		if len(events) == limit {
+			break
+		}
+		if !e.Published && e.Attempts < maxAttempts {
+			events = append(events, e.Event)
+		}
+	}
+	unlock()
+
+	results := make(map[string]error, len(events))
+	published := 0
+	for _, event := range events {
+		results[event.Id] = publish(ctx, event)
+		if results[event.Id] == nil {
+			published++
+		}
+	}
+
+	defer r.lock(ctx)()
+	for i, e := range r.data.outbox {
+		pubErr, ok := results[e.Id]
+		if !ok {
+			continue
+		}
+		if pubErr != nil {
+			r.data.outbox[i].Attempts++
+			r.data.outbox[i].LastError = pubErr.Error()
+		} else {
+			r.data.outbox[i].Published = true
+		}
+	}
+	return published, nil
+}
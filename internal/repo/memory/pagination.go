@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"tender-service/internal/entity"
+)
+
+// orderKey колонка сортировки keyset-пагинации: сравнение значений в строковом виде курсора
+type orderKey struct {
+	Compare func(a, b string) int
+	Desc    bool
+}
+
+func compareTime(a, b string) int {
+	ta, _ := time.Parse(time.RFC3339Nano, a)
+	tb, _ := time.Parse(time.RFC3339Nano, b)
+	return ta.Compare(tb)
+}
+
+func compareInt(a, b string) int {
+	ia, _ := strconv.Atoi(a)
+	ib, _ := strconv.Atoi(b)
+	return cmp.Compare(ia, ib)
+}
+
+func normalizeLimit(limit int) int {
+	if limit > maxPaginationLimit {
+		return maxPaginationLimit
+	}
+	if limit <= 0 {
+		return defaultPaginationLimit
+	}
+	return limit
+}
+
+// offsetLimit страница items для пагинации limit/offset
+func offsetLimit[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit = normalizeLimit(limit); len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// compareCursors порядок курсоров по keys, затем по id
+func compareCursors(keys []orderKey, a, b entity.Cursor) int {
+	for i, k := range keys {
+		c := k.Compare(a.Keys[i], b.Keys[i])
+		if k.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return strings.Compare(a.Id, b.Id)
+}
+
+// keyset упорядочивает items по keys и id и возвращает страницу p, как одноименная функция pgdb
+func keyset[T any](items []T, p entity.Pagination, keys []orderKey, key func(T) entity.Cursor) (
+	entity.Page[T], error,
+) {
+	if p.After != nil && len(p.After.Keys) != len(keys) {
+		return entity.Page[T]{}, fmt.Errorf("cursor has %d keys, sort has %d", len(p.After.Keys), len(keys))
+	}
+
+	slices.SortFunc(items, func(a, b T) int {
+		return compareCursors(keys, key(a), key(b))
+	})
+
+	if p.After != nil {
+		start := len(items)
+		for i, item := range items {
+			if compareCursors(keys, key(item), *p.After) > 0 {
+				start = i
+				break
+			}
+		}
+		items = items[start:]
+	} else if p.Offset > 0 {
+		items = items[min(p.Offset, len(items)):]
+	}
+
+	limit := normalizeLimit(p.Limit)
+	if len(items) <= limit {
+		if len(items) == 0 {
+			items = nil
+		}
+		return entity.Page[T]{Items: items}, nil
+	}
+	items = items[:limit]
+	next := key(items[limit-1])
+	return entity.Page[T]{Items: items, Next: &next}, nil
+}
+
+// bidOrder предложения всегда упорядочены по названию
+var bidOrder = []orderKey{{Compare: strings.Compare}}
+
+func bidCursor(b entity.Bid) entity.Cursor {
+	return entity.Cursor{Keys: []string{b.Name}, Id: b.Id}
+}
+
+// tenderSortColumn сравнение колонки сортировки тендеров и значение для курсора
+type tenderSortColumn struct {
+	compare func(a, b string) int
+	value   func(entity.Tender) string
+}
+
+// tenderSortColumns allow-list сортировок списка тендеров
+var tenderSortColumns = map[string]tenderSortColumn{
+	entity.TenderSortName: {
+		compare: strings.Compare,
+		value:   func(t entity.Tender) string { return t.Name },
+	},
+	entity.TenderSortCreatedAt: {
+		compare: compareTime,
+		value:   func(t entity.Tender) string { return t.CreatedAt.Format(time.RFC3339Nano) },
+	},
+	entity.TenderSortVersion: {
+		compare: compareInt,
+		value:   func(t entity.Tender) string { return strconv.Itoa(t.Version) },
+	},
+}
+
+// tenderOrder колонки keyset и построитель курсора для сортировки sort (по умолчанию - по названию)
+func tenderOrder(sort []entity.SortField) ([]orderKey, func(entity.Tender) entity.Cursor, error) {
+	if len(sort) == 0 {
+		sort = []entity.SortField{{Field: entity.TenderSortName}}
+	}
+
+	keys := make([]orderKey, 0, len(sort))
+	values := make([]func(entity.Tender) string, 0, len(sort))
+	for _, f := range sort {
+		column, ok := tenderSortColumns[f.Field]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown sort field %q", f.Field)
+		}
+		keys = append(keys, orderKey{Compare: column.compare, Desc: f.Desc})
+		values = append(values, column.value)
+	}
+
+	cursor := func(t entity.Tender) entity.Cursor {
+		c := entity.Cursor{Keys: make([]string, 0, len(values)), Id: t.Id}
+		for _, v := range values {
+			c.Keys = append(c.Keys, v(t))
+		}
+		return c
+	}
+	return keys, cursor, nil
+}
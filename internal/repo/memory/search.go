@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// searchLanguages поддерживаемые конфигурации поиска, как в pgdb
+var searchLanguages = map[string]bool{
+	"russian": true,
+	"english": true,
+}
+
+// searchTerms слова запроса в нижнем регистре; кавычки и операторы websearch отбрасываются
+func searchTerms(q string) []string {
+	var terms []string
+	for _, f := range strings.Fields(strings.ToLower(q)) {
+		f = strings.Trim(f, `"-`)
+		if f == "" || f == "or" {
+			continue
+		}
+		terms = append(terms, f)
+	}
+	return terms
+}
+
+// rank релевантность по подстрокам без стемминга: совпадение в названии весит больше, чем в описании.
+// 0 - не все слова запроса найдены
+func rank(terms []string, name, description string) int {
+	name, description = strings.ToLower(name), strings.ToLower(description)
+
+	score := 0
+	for _, t := range terms {
+		switch {
+		case strings.Contains(name, t):
+			score += 2
+		case strings.Contains(description, t):
+			score++
+		default:
+			return 0
+		}
+	}
+	return score
+}
+
+// fullText упрощенный аналог полнотекстового поиска pgdb: найденные items по убыванию релевантности, затем по id
+func fullText[T any](items []T, language, q string, text func(T) (id, name, description string)) ([]T, error) {
+	if !searchLanguages[language] {
+		return nil, fmt.Errorf("unsupported search language %q", language)
+	}
+
+	terms := searchTerms(q)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	type ranked struct {
+		item  T
+		id    string
+		score int
+	}
+	var found []ranked
+	for _, item := range items {
+		id, name, description := text(item)
+		if score := rank(terms, name, description); score > 0 {
+			found = append(found, ranked{item: item, id: id, score: score})
+		}
+	}
+
+	slices.SortFunc(found, func(a, b ranked) int {
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.id, b.id)
+	})
+
+	output := make([]T, 0, len(found))
+	for _, f := range found {
+		output = append(output, f.item)
+	}
+	return output, nil
+}
@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+)
+
+const (
+	tenderStatusCreated   = "Created"
+	tenderStatusPublished = "Published"
+	tenderStatusClosed    = "Closed"
+
+	bidStatusCreated   = "Created"
+	bidStatusPublished = "Published"
+	bidStatusCanceled  = "Canceled"
+	bidStatusApproved  = "Approved"
+	bidStatusRejected  = "Rejected"
+
+	bidDecisionApproved = "Approved"
+	bidDecisionRejected = "Rejected"
+
+	maxPaginationLimit     = 50
+	defaultPaginationLimit = 5
+)
+
+// errForeignKey ссылка на несуществующую запись, аналог нарушения внешнего ключа в postgres
+var errForeignKey = errors.New("foreign key violation")
+
+// data таблицы хранилища
+type data struct {
+	users         map[string]entity.User
+	organizations map[string]entity.Organization
+	responsibles  map[string]entity.OrgResponsible
+	tenders       map[string]entity.Tender
+	tenderHistory map[string][]entity.Tender
+	bids          map[string]entity.Bid
+	bidHistory    map[string][]entity.Bid
+	decisions     []entity.BidDecision
+	reviews       []entity.BidReview
+	outbox        []outboxEvent
+	webhooks      map[string]entity.Webhook
+	deliveries    []entity.WebhookDelivery
+	audit         []entity.AuditEntry
+}
+
+func newData() *data {
+	return &data{
+		users:         make(map[string]entity.User),
+		organizations: make(map[string]entity.Organization),
+		responsibles:  make(map[string]entity.OrgResponsible),
+		tenders:       make(map[string]entity.Tender),
+		tenderHistory: make(map[string][]entity.Tender),
+		bids:          make(map[string]entity.Bid),
+		bidHistory:    make(map[string][]entity.Bid),
+		webhooks:      make(map[string]entity.Webhook),
+	}
+}
+
+// clone копия таблиц для отката транзакции
+func (d *data) clone() *data {
+	c := &data{
+		users:         maps.Clone(d.users),
+		organizations: maps.Clone(d.organizations),
+		responsibles:  maps.Clone(d.responsibles),
+		tenders:       maps.Clone(d.tenders),
+		tenderHistory: make(map[string][]entity.Tender, len(d.tenderHistory)),
+		bids:          maps.Clone(d.bids),
+		bidHistory:    make(map[string][]entity.Bid, len(d.bidHistory)),
+		decisions:     slices.Clone(d.decisions),
+		reviews:       slices.Clone(d.reviews),
+		outbox:        slices.Clone(d.outbox),
+		webhooks:      maps.Clone(d.webhooks),
+		deliveries:    slices.Clone(d.deliveries),
+		audit:         slices.Clone(d.audit),
+	}
+	for id, h := range d.tenderHistory {
+		c.tenderHistory[id] = slices.Clone(h)
+	}
+	for id, h := range d.bidHistory {
+		c.bidHistory[id] = slices.Clone(h)
+	}
+	return c
+}
+
+// responsibleOrgs неудаленные организации, за которые отвечает пользователь
+func (d *data) responsibleOrgs(userId string) map[string]bool {
+	orgs := make(map[string]bool)
+	for _, r := range d.responsibles {
+		if r.UserId == userId && d.organizations[r.OrganizationId].DeletedAt == nil {
+			orgs[r.OrganizationId] = true
+		}
+	}
+	return orgs
+}
+
+// Store общее хранилище репозиториев в памяти процесса.
+// Каждый вызов репозитория атомарен, TxManager.Do выполняет несколько вызовов изолированно и откатывает их при ошибке
+type Store struct {
+	mu   sync.Mutex
+	data *data
+
+	// dispatchMu не дает двум Dispatch отправить одно событие дважды
+	dispatchMu sync.Mutex
+}
+
+func NewStore() *Store {
+	return &Store{data: newData()}
+}
+
+type txCtxKey struct{}
+
+// lock блокирует хранилище; внутри TxManager.Do хранилище уже заблокировано
+func (s *Store) lock(ctx context.Context) func() {
+	if tx, ok := ctx.Value(txCtxKey{}).(*Store); ok && tx == s {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+// TxManager транзакции хранилища в памяти: на время fn хранилище блокируется целиком,
+// при ошибке fn все изменения откатываются
+type TxManager struct {
+	store *Store
+}
+
+func NewTxManager(store *Store) *TxManager {
+	return &TxManager{store: store}
+}
+
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txCtxKey{}).(*Store); ok && tx == m.store {
+		return fn(ctx)
+	}
+
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	snapshot := m.store.data.clone()
+	if err := fn(context.WithValue(ctx, txCtxKey{}, m.store)); err != nil {
+		m.store.data = snapshot
+		return err
+	}
+	return nil
+}
+
+// NewRepositories репозитории поверх нового пустого хранилища в памяти
+func NewRepositories() *repo.Repositories {
+	store := NewStore()
+	return &repo.Repositories{
+		TxManager:      NewTxManager(store),
+		User:           NewUserRepo(store),
+		Organization:   NewOrganizationRepo(store),
+		OrgResponsible: NewOrgResponsibleRepo(store),
+		Tender:         NewTenderRepo(store),
+		Bid:            NewBidRepo(store),
+		BidDecision:    NewBidDecisionRepo(store),
+		BidReview:      NewBidReviewRepo(store),
+		Outbox:         NewOutboxRepo(store),
+		Webhook:        NewWebhookRepo(store),
+		Audit:          NewAuditRepo(store),
+	}
+}
+
+// newId случайный UUID v4, как uuid_generate_v4()
+func newId() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("memory - newId - rand.Read: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// now текущее время с точностью postgres TIMESTAMP
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
@@ -0,0 +1,312 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+)
+
+type TenderRepo struct {
+	*Store
+}
+
+func NewTenderRepo(store *Store) *TenderRepo {
+	return &TenderRepo{store}
+}
+
+func (r *TenderRepo) Create(ctx context.Context, input entity.Tender) (entity.Tender, error) {
+	defer r.lock(ctx)()
+
+	if _, ok := r.data.organizations[input.OrganizationId]; !ok {
+		return entity.Tender{}, fmt.Errorf("TenderRepo - Create - organization: %w", errForeignKey)
+	}
+	creatorExists := false
+	for _, u := range r.data.users {
+		if u.Username == input.CreatorUsername {
+			creatorExists = true
+			break
+		}
+	}
+	if !creatorExists {
+		return entity.Tender{}, fmt.Errorf("TenderRepo - Create - creator: %w", errForeignKey)
+	}
+
+	output := entity.Tender{
+		Id:              newId(),
+		Name:            input.Name,
+		Description:     input.Description,
+		ServiceType:     input.ServiceType,
+		Status:          tenderStatusCreated,
+		OrganizationId:  input.OrganizationId,
+		Version:         1,
+		CreatedAt:       now(),
+		CreatorUsername: input.CreatorUsername,
+	}
+	r.data.tenders[output.Id] = output
+	r.data.saveTenderHistory(output.Id)
+	return output, nil
+}
+
+func (r *TenderRepo) GetById(ctx context.Context, id string) (entity.Tender, error) {
+	defer r.lock(ctx)()
+
+	output, ok := r.data.tenders[id]
+	if !ok {
+		return entity.Tender{}, repoerrs.ErrNotFound
+	}
+	return output, nil
+}
+
+// visibleTenders опубликованные тендеры видны всем, остальные - только ответственным за организацию
+func (d *data) visibleTenders(userId string, match func(entity.Tender) bool) []entity.Tender {
+	orgs := d.responsibleOrgs(userId)
+
+	var output []entity.Tender
+	for _, t := range d.tenders {
+		if t.Status != tenderStatusPublished && !orgs[t.OrganizationId] {
+			continue
+		}
+		if match(t) {
+			output = append(output, t)
+		}
+	}
+	return output
+}
+
+// matchTenderFilter тендер подходит под filter; пустые поля не ограничивают выборку
+func matchTenderFilter(t entity.Tender, filter entity.TenderFilter) bool {
+	if len(filter.ServiceType) != 0 && !slices.Contains(filter.ServiceType, t.ServiceType) {
+		return false
+	}
+	if len(filter.Status) != 0 && !slices.Contains(filter.Status, t.Status) {
+		return false
+	}
+	if filter.OrganizationId != "" && t.OrganizationId != filter.OrganizationId {
+		return false
+	}
+	if filter.CreatorUsername != "" && t.CreatorUsername != filter.CreatorUsername {
+		return false
+	}
+	if filter.CreatedAfter != nil && t.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && !t.CreatedAt.Before(*filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// GetByTypePagination видимые пользователю тендеры, подходящие под filter.
+// Total заполняется по тем же правилам, что и в pgdb
+func (r *TenderRepo) GetByTypePagination(
+	ctx context.Context, page entity.Pagination, filter entity.TenderFilter, userId string,
+) (
+	entity.Page[entity.Tender], error,
+) {
+	defer r.lock(ctx)()
+
+	keys, cursor, err := tenderOrder(page.Sort)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetByTypePagination - tenderOrder: %w", err)
+	}
+
+	filtered := r.data.visibleTenders(userId, func(t entity.Tender) bool {
+		return matchTenderFilter(t, filter)
+	})
+	total := len(filtered)
+
+	result, err := keyset(filtered, page, keys, cursor)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetByTypePagination - keyset: %w", err)
+	}
+
+	// по пустой странице после курсора или смещения общее число не определить
+	if page.WithTotal && (len(result.Items) != 0 || (page.After == nil && page.Offset == 0)) {
+		result.Total = &total
+	}
+	return result, nil
+}
+
+func (r *TenderRepo) GetMyPagination(ctx context.Context, page entity.Pagination, username string) (
+	entity.Page[entity.Tender], error,
+) {
+	defer r.lock(ctx)()
+
+	keys, cursor, err := tenderOrder(page.Sort)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetMyPagination - tenderOrder: %w", err)
+	}
+
+	var output []entity.Tender
+	for _, t := range r.data.tenders {
+		if t.CreatorUsername == username {
+			output = append(output, t)
+		}
+	}
+
+	result, err := keyset(output, page, keys, cursor)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetMyPagination - keyset: %w", err)
+	}
+	return result, nil
+}
+
+// Search видимые пользователю тендеры, найденные по названию и описанию, по убыванию релевантности
+func (r *TenderRepo) Search(ctx context.Context, page entity.Pagination, language, q, userId string) (
+	[]entity.Tender, error,
+) {
+	defer r.lock(ctx)()
+
+	visible := r.data.visibleTenders(userId, func(entity.Tender) bool { return true })
+	output, err := fullText(visible, language, q, func(t entity.Tender) (string, string, string) {
+		return t.Id, t.Name, t.Description
+	})
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepo - Search - fullText: %w", err)
+	}
+	return offsetLimit(output, page.Limit, page.Offset), nil
+}
+
+func (r *TenderRepo) PutStatus(ctx context.Context, tenderId, status string) error {
+	defer r.lock(ctx)()
+
+	t, ok := r.data.tenders[tenderId]
+	if !ok {
+		return repoerrs.ErrNotFound
+	}
+
+	prevStatus := t.Status
+	t.Status = status
+	r.data.tenders[tenderId] = t
+
+	if prevStatus != status {
+		if err := r.data.saveTenderStatusEvent(tenderId, status); err != nil {
+			return fmt.Errorf("TenderRepo.PutStatus - saveTenderStatusEvent: %w", err)
+		}
+	}
+	return nil
+}
+
+// EditTender меняет непустые поля; отсутствующий тендер не считается ошибкой, как и в pgdb
+func (r *TenderRepo) EditTender(ctx context.Context, input entity.Tender, tenderId string) error {
+	defer r.lock(ctx)()
+
+	t, ok := r.data.tenders[tenderId]
+	if !ok {
+		return nil
+	}
+
+	if input.Name != "" {
+		t.Name = input.Name
+	}
+	if input.Description != "" {
+		t.Description = input.Description
+	}
+	if input.ServiceType != "" {
+		t.ServiceType = input.ServiceType
+	}
+	r.data.tenders[tenderId] = t
+	return nil
+}
+
+// IncrementVersion увеличивает версию и сохраняет снимок в историю. При expectedVersion > 0
+// текущая версия должна с ней совпасть, иначе *repoerrs.VersionConflictError
+func (r *TenderRepo) IncrementVersion(ctx context.Context, tenderId string, expectedVersion int) error {
+	defer r.lock(ctx)()
+
+	t, ok := r.data.tenders[tenderId]
+	if !ok {
+		return repoerrs.ErrNotFound
+	}
+	if expectedVersion > 0 && t.Version != expectedVersion {
+		return &repoerrs.VersionConflictError{CurrentVersion: t.Version}
+	}
+
+	t.Version++
+	r.data.tenders[tenderId] = t
+	r.data.saveTenderHistory(tenderId)
+	return nil
+}
+
+// setTenderStatus меняет статус тендера, инкрементирует версию и сохраняет снимок
+func (d *data) setTenderStatus(tenderId, status string) error {
+	t := d.tenders[tenderId]
+	t.Status = status
+	t.Version++
+	d.tenders[tenderId] = t
+
+	if err := d.saveTenderStatusEvent(tenderId, status); err != nil {
+		return fmt.Errorf("setTenderStatus - saveTenderStatusEvent: %w", err)
+	}
+
+	d.saveTenderHistory(tenderId)
+	return nil
+}
+
+// saveTenderStatusEvent пишет в outbox событие публикации или закрытия тендера, остальные статусы событий не порождают
+func (d *data) saveTenderStatusEvent(tenderId, status string) error {
+	var eventType entity.EventType
+	switch status {
+	case tenderStatusPublished:
+		eventType = entity.EventTenderPublished
+	case tenderStatusClosed:
+		eventType = entity.EventTenderClosed
+	default:
+		return nil
+	}
+
+	return d.saveEvent(eventType, tenderId, entity.TenderStatusPayload{
+		TenderId:       tenderId,
+		OrganizationId: d.tenders[tenderId].OrganizationId,
+		Status:         status,
+	})
+}
+
+// saveTenderHistory сохраняет снимок текущего состояния тендера
+func (d *data) saveTenderHistory(tenderId string) {
+	d.tenderHistory[tenderId] = append(d.tenderHistory[tenderId], d.tenders[tenderId])
+}
+
+func (r *TenderRepo) GetVersion(ctx context.Context, tenderId string, version int) (entity.Tender, error) {
+	defer r.lock(ctx)()
+
+	return r.data.tenderVersion(tenderId, version)
+}
+
+func (d *data) tenderVersion(tenderId string, version int) (entity.Tender, error) {
+	for _, t := range d.tenderHistory[tenderId] {
+		if t.Version == version {
+			return t, nil
+		}
+	}
+	return entity.Tender{}, repoerrs.ErrNotFound
+}
+
+func (r *TenderRepo) GetVersions(ctx context.Context, tenderId string) ([]entity.Tender, error) {
+	defer r.lock(ctx)()
+
+	return slices.Clone(r.data.tenderHistory[tenderId]), nil
+}
+
+// Rollback восстанавливает параметры тендера из снимка version как новую версию
+func (r *TenderRepo) Rollback(ctx context.Context, tenderId string, version int) error {
+	defer r.lock(ctx)()
+
+	snapshot, err := r.data.tenderVersion(tenderId, version)
+	if err != nil {
+		return err
+	}
+
+	t := r.data.tenders[tenderId]
+	t.Name = snapshot.Name
+	t.Description = snapshot.Description
+	t.ServiceType = snapshot.ServiceType
+	t.Version++
+	r.data.tenders[tenderId] = t
+
+	r.data.saveTenderHistory(tenderId)
+	return nil
+}
@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+)
+
+type UserRepo struct {
+	*Store
+}
+
+func NewUserRepo(store *Store) *UserRepo {
+	return &UserRepo{store}
+}
+
+func (r *UserRepo) Create(ctx context.Context, user entity.User) (string, error) {
+	defer r.lock(ctx)()
+
+	for _, u := range r.data.users {
+		if u.Username == user.Username {
+			return "", repoerrs.ErrAlreadyExists
+		}
+	}
+
+	t := now()
+	user = entity.User{
+		Id:        newId(),
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		CreatedAt: t,
+		UpdatedAt: t,
+	}
+	r.data.users[user.Id] = user
+	return user.Id, nil
+}
+
+func (r *UserRepo) GetById(ctx context.Context, id string) (entity.User, error) {
+	defer r.lock(ctx)()
+
+	user, ok := r.data.users[id]
+	if !ok {
+		return entity.User{}, repoerrs.ErrNotFound
+	}
+	return user, nil
+}
+
+func (r *UserRepo) GetByUsername(ctx context.Context, username string) (entity.User, error) {
+	defer r.lock(ctx)()
+
+	for _, u := range r.data.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return entity.User{}, repoerrs.ErrNotFound
+}
+
+// GetPagination список пользователей, query - поиск по username, имени и фамилии без учета регистра
+func (r *UserRepo) GetPagination(ctx context.Context, limit, offset int, query string) ([]entity.User, error) {
+	defer r.lock(ctx)()
+
+	query = strings.ToLower(query)
+
+	var output []entity.User
+	for _, u := range r.data.users {
+		if query != "" &&
+			!strings.Contains(strings.ToLower(u.Username), query) &&
+			!strings.Contains(strings.ToLower(u.FirstName), query) &&
+			!strings.Contains(strings.ToLower(u.LastName), query) {
+			continue
+		}
+		output = append(output, u)
+	}
+	slices.SortFunc(output, func(a, b entity.User) int {
+		return strings.Compare(a.Username, b.Username)
+	})
+
+	return offsetLimit(output, limit, offset), nil
+}
+
+// Update меняет непустые поля профиля активного пользователя и обновляет updated_at
+func (r *UserRepo) Update(ctx context.Context, id string, input entity.User) (entity.User, error) {
+	defer r.lock(ctx)()
+
+	user, ok := r.data.users[id]
+	if !ok || !user.IsActive() {
+		return entity.User{}, repoerrs.ErrNotFound
+	}
+
+	if input.FirstName != "" {
+		user.FirstName = input.FirstName
+	}
+	if input.LastName != "" {
+		user.LastName = input.LastName
+	}
+	user.UpdatedAt = now()
+
+	r.data.users[id] = user
+	return user, nil
+}
+
+// Deactivate помечает пользователя деактивированным, его тендеры и предложения сохраняются
+func (r *UserRepo) Deactivate(ctx context.Context, id string) error {
+	defer r.lock(ctx)()
+
+	user, ok := r.data.users[id]
+	if !ok || !user.IsActive() {
+		return repoerrs.ErrNotFound
+	}
+
+	t := now()
+	user.DeactivatedAt = &t
+	user.UpdatedAt = t
+
+	r.data.users[id] = user
+	return nil
+}
@@ -0,0 +1,249 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+)
+
+type WebhookRepo struct {
+	*Store
+}
+
+func NewWebhookRepo(store *Store) *WebhookRepo {
+	return &WebhookRepo{store}
+}
+
+func (r *WebhookRepo) Create(ctx context.Context, input entity.Webhook) (entity.Webhook, error) {
+	defer r.lock(ctx)()
+
+	if _, ok := r.data.organizations[input.OrganizationId]; !ok {
+		return entity.Webhook{}, fmt.Errorf("WebhookRepo - Create - organization: %w", errForeignKey)
+	}
+
+	output := entity.Webhook{
+		Id:             newId(),
+		OrganizationId: input.OrganizationId,
+		Url:            input.Url,
+		Secret:         input.Secret,
+		EventTypes:     slices.Clone(input.EventTypes),
+		CreatedAt:      now(),
+	}
+	r.data.webhooks[output.Id] = output
+	return output, nil
+}
+
+func (r *WebhookRepo) GetById(ctx context.Context, id string) (entity.Webhook, error) {
+	defer r.lock(ctx)()
+
+	output, ok := r.data.webhooks[id]
+	if !ok {
+		return entity.Webhook{}, repoerrs.ErrNotFound
+	}
+	return output, nil
+}
+
+func (r *WebhookRepo) GetByOrganization(ctx context.Context, organizationId string) ([]entity.Webhook, error) {
+	defer r.lock(ctx)()
+
+	var output []entity.Webhook
+	for _, w := range r.data.webhooks {
+		if w.OrganizationId == organizationId {
+			output = append(output, w)
+		}
+	}
+	slices.SortFunc(output, func(a, b entity.Webhook) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+	return output, nil
+}
+
+// Delete удаляет подписку организации вместе с журналом доставок
+func (r *WebhookRepo) Delete(ctx context.Context, organizationId, id string) error {
+	defer r.lock(ctx)()
+
+	w, ok := r.data.webhooks[id]
+	if !ok || w.OrganizationId != organizationId {
+		return repoerrs.ErrNotFound
+	}
+
+	delete(r.data.webhooks, id)
+	r.data.deliveries = slices.DeleteFunc(r.data.deliveries, func(d entity.WebhookDelivery) bool {
+		return d.WebhookId == id
+	})
+	return nil
+}
+
+// GetDeliveries журнал доставок подписки, новые первыми
+func (r *WebhookRepo) GetDeliveries(ctx context.Context, webhookId string, limit, offset int) (
+	[]entity.WebhookDelivery, error,
+) {
+	defer r.lock(ctx)()
+
+	var output []entity.WebhookDelivery
+	for _, d := range r.data.deliveries {
+		if d.WebhookId == webhookId {
+			output = append(output, d)
+		}
+	}
+	slices.SortFunc(output, func(a, b entity.WebhookDelivery) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	return offsetLimit(output, limit, offset), nil
+}
+
+// eventOrganizations организации, которых касается событие:
+// для тендера и нового предложения - организация тендера, для решения - автор предложения
+func (d *data) eventOrganizations(event entity.Event) map[string]bool {
+	orgs := make(map[string]bool)
+	switch event.Type {
+	case entity.EventTenderPublished, entity.EventTenderClosed:
+		if t, ok := d.tenders[event.AggregateId]; ok {
+			orgs[t.OrganizationId] = true
+		}
+	case entity.EventBidCreated:
+		if b, ok := d.bids[event.AggregateId]; ok {
+			orgs[d.tenders[b.TenderId].OrganizationId] = true
+		}
+	case entity.EventBidApproved, entity.EventBidRejected:
+		b, ok := d.bids[event.AggregateId]
+		if !ok {
+			break
+		}
+		if b.AuthorType == entity.BidAuthorTypeOrganization {
+			orgs[b.AuthorId] = true
+		} else {
+			orgs = d.responsibleOrgs(b.AuthorId)
+		}
+	}
+	return orgs
+}
+
+// Enqueue создает доставки события body по всем подходящим подпискам затронутых организаций.
+// Повторный вызов для того же события новых доставок не создает. Возвращает число созданных доставок.
+func (r *WebhookRepo) Enqueue(ctx context.Context, event entity.Event, body []byte) (int, error) {
+	defer r.lock(ctx)()
+
+	orgs := r.data.eventOrganizations(event)
+
+	created := 0
+	for _, w := range r.data.webhooks {
+		if !orgs[w.OrganizationId] || !slices.Contains(w.EventTypes, string(event.Type)) {
+			continue
+		}
+		if o := r.data.organizations[w.OrganizationId]; o.DeletedAt != nil {
+			continue
+		}
+		if slices.ContainsFunc(r.data.deliveries, func(d entity.WebhookDelivery) bool {
+			return d.WebhookId == w.Id && d.EventId == event.Id
+		}) {
+			continue
+		}
+
+		t := now()
+		r.data.deliveries = append(r.data.deliveries, entity.WebhookDelivery{
+			Id:            newId(),
+			WebhookId:     w.Id,
+			EventId:       event.Id,
+			EventType:     string(event.Type),
+			Payload:       slices.Clone(body),
+			Status:        entity.WebhookDeliveryPending,
+			NextAttemptAt: t,
+			CreatedAt:     t,
+		})
+		created++
+	}
+	return created, nil
+}
+
+// ClaimDue захватывает до limit доставок, срок которых наступил, сдвигая следующую попытку на lease:
+// если отправитель упадет, доставка будет повторена после истечения lease.
+func (r *WebhookRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) (
+	[]entity.WebhookDelivery, error,
+) {
+	defer r.lock(ctx)()
+
+	t := now()
+	var due []int
+	for i, d := range r.data.deliveries {
+		if d.Status == entity.WebhookDeliveryPending && !d.NextAttemptAt.After(t) {
+			due = append(due, i)
+		}
+	}
+	slices.SortStableFunc(due, func(a, b int) int {
+		return r.data.deliveries[a].NextAttemptAt.Compare(r.data.deliveries[b].NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	output := make([]entity.WebhookDelivery, 0, len(due))
+	for _, i := range due {
+		r.data.deliveries[i].NextAttemptAt = t.Add(lease)
+
+		d := r.data.deliveries[i]
+		w := r.data.webhooks[d.WebhookId]
+		d.Url, d.Secret = w.Url, w.Secret
+		output = append(output, d)
+	}
+	return output, nil
+}
+
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, id string, responseCode int) error {
+	defer r.lock(ctx)()
+
+	for i, d := range r.data.deliveries {
+		if d.Id != id {
+			continue
+		}
+		t := now()
+		d.Status = entity.WebhookDeliveryDelivered
+		d.Attempts++
+		d.ResponseCode = &responseCode
+		d.LastError = nil
+		d.DeliveredAt = &t
+		r.data.deliveries[i] = d
+		break
+	}
+	return nil
+}
+
+// MarkFailed фиксирует неудачную попытку. retryAfter > 0 планирует повтор,
+// иначе доставка окончательно переходит в Failed. responseCode 0 - ответа не было.
+func (r *WebhookRepo) MarkFailed(
+	ctx context.Context, id string, responseCode int, lastError string, retryAfter time.Duration,
+) error {
+	defer r.lock(ctx)()
+
+	for i, d := range r.data.deliveries {
+		if d.Id != id {
+			continue
+		}
+		d.Attempts++
+		d.ResponseCode = nil
+		if responseCode != 0 {
+			d.ResponseCode = &responseCode
+		}
+		d.LastError = &lastError
+		if retryAfter > 0 {
+			d.NextAttemptAt = now().Add(retryAfter)
+		} else {
+			d.Status = entity.WebhookDeliveryFailed
+		}
+		r.data.deliveries[i] = d
+		break
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package pgdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
+	"tender-service/pkg/postgres"
+)
+
+const auditLogTable = "audit_log"
+
+var auditLogColumns = []string{
+	"id", "actor_id", "actor_username", "action", "entity_type", "entity_id",
+	"organization_id", "old_version", "new_version", "request_id", "created_at",
+}
+
+type AuditRepo struct {
+	*postgres.Database
+}
+
+func NewAuditRepo(db *postgres.Database) *AuditRepo {
+	return &AuditRepo{db}
+}
+
+// entityOrganization организация, которой принадлежит сущность: для предложения - организация тендера
+func entityOrganization(entityType, entityId string) squirrel.Sqlizer {
+	switch entityType {
+	case entity.AuditEntityTender:
+		return squirrel.Expr("(SELECT organization_id FROM "+tender+" WHERE id = ?)", entityId)
+	case entity.AuditEntityBid:
+		return squirrel.Expr(
+			"(SELECT t.organization_id FROM "+bidTable+" b JOIN "+tender+" t ON t.id = b.tender_id WHERE b.id = ?)",
+			entityId,
+		)
+	}
+	return squirrel.Expr("NULL")
+}
+
+func (r *AuditRepo) Create(ctx context.Context, input entity.AuditEntry) error {
+	sql, args, err := r.Builder.
+		Insert(auditLogTable).
+		Columns(
+			"actor_id", "actor_username", "action", "entity_type", "entity_id",
+			"organization_id", "old_version", "new_version", "request_id",
+		).
+		Values(
+			input.ActorId, input.ActorUsername, input.Action, input.EntityType, input.EntityId,
+			entityOrganization(input.EntityType, input.EntityId), input.OldVersion, input.NewVersion, input.RequestId,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("AuditRepo - Create - r.Builder: %w", err)
+	}
+
+	if _, err = r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("AuditRepo - Create - r.Conn.Exec: %w", err)
+	}
+	return nil
+}
+
+// GetByEntity журнал сущности, новые записи первыми. Возвращаются только записи неудаленных организаций,
+// за которые отвечает userId
+func (r *AuditRepo) GetByEntity(ctx context.Context, entityId, userId string, limit, offset int) (
+	[]entity.AuditEntry, error,
+) {
+	sql, args, _ := r.Builder.
+		Select(auditLogColumns...).
+		From(auditLogTable).
+		Where("entity_id = ?", entityId).
+		Where("organization_id IN ("+responsibleOrgIds+")", userId).
+		OrderBy("created_at DESC", "id").
+		Limit(uint64(normalizeLimit(limit))).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AuditRepo - GetByEntity - r.Conn.Query: %w", err)
+	}
+	output, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.AuditEntry, error) {
+		var e entity.AuditEntry
+		err := row.Scan(
+			&e.Id,
+			&e.ActorId,
+			&e.ActorUsername,
+			&e.Action,
+			&e.EntityType,
+			&e.EntityId,
+			&e.OrganizationId,
+			&e.OldVersion,
+			&e.NewVersion,
+			&e.RequestId,
+			&e.CreatedAt,
+		)
+		return e, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("AuditRepo - GetByEntity - pgx.CollectRows: %w", err)
+	}
+	return output, nil
+}
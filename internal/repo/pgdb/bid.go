@@ -4,8 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -15,9 +15,40 @@ import (
 )
 
 const (
-	bidTable = "bid"
+	bidTable        = "bid"
+	bidHistoryTable = "bid_history"
+
+	bidStatusCreated   = "Created"
+	bidStatusPublished = "Published"
+	bidStatusCanceled  = "Canceled"
+	bidStatusApproved  = "Approved"
+	bidStatusRejected  = "Rejected"
 )
 
+// bidColumns колонки предложения в порядке полей entity.Bid
+var bidColumns = []string{
+	"id", "name", "description", "status", "tender_id",
+	"author_type", "author_id", "version", "created_at",
+}
+
+// authoredBy условие на предложения, автором которых является пользователь userId
+// лично или организация, за которую он отвечает
+func authoredBy(alias, userId string) squirrel.Sqlizer {
+	return squirrel.Or{
+		squirrel.And{
+			squirrel.Eq{alias + "author_type": entity.BidAuthorTypeUser},
+			squirrel.Eq{alias + "author_id": userId},
+		},
+		squirrel.And{
+			squirrel.Eq{alias + "author_type": entity.BidAuthorTypeOrganization},
+			squirrel.Expr(
+				alias+"author_id IN ("+responsibleOrgIds+")",
+				userId,
+			),
+		},
+	}
+}
+
 type BidRepo struct {
 	*postgres.Database
 }
@@ -45,7 +76,7 @@ func (r *BidRepo) Create(ctx context.Context, input entity.Bid) (entity.Bid, err
 	).ToSql()
 
 	var output entity.Bid
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&output.Id,
 		&output.Name,
 		&output.Description,
@@ -63,20 +94,34 @@ func (r *BidRepo) Create(ctx context.Context, input entity.Bid) (entity.Bid, err
 				return entity.Bid{}, repoerrs.ErrAlreadyExists
 			}
 		}
-		return entity.Bid{}, fmt.Errorf("BidRepo - Create - r.Cluster.QueryRow: %v", err)
+		return entity.Bid{}, fmt.Errorf("BidRepo - Create - r.Conn.QueryRow: %w", err)
+	}
+
+	if err = r.saveHistory(ctx, output.Id); err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepo - Create - r.saveHistory: %w", err)
+	}
+
+	err = saveEvent(ctx, r.Conn(ctx), r.Builder, entity.EventBidCreated, output.Id, entity.BidCreatedPayload{
+		BidId:      output.Id,
+		TenderId:   output.TenderId,
+		AuthorType: output.AuthorType,
+		AuthorId:   output.AuthorId,
+	})
+	if err != nil {
+		return entity.Bid{}, fmt.Errorf("BidRepo - Create - saveEvent: %w", err)
 	}
 	return output, nil
 }
 
 func (r *BidRepo) GetById(ctx context.Context, bidId string) (entity.Bid, error) {
 	sql, args, _ := r.Builder.
-		Select("*").
+		Select(bidColumns...).
 		From(bidTable).
 		Where("id = ?", bidId).
 		ToSql()
 
 	var output entity.Bid
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&output.Id,
 		&output.Name,
 		&output.Description,
@@ -91,92 +136,147 @@ func (r *BidRepo) GetById(ctx context.Context, bidId string) (entity.Bid, error)
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Bid{}, repoerrs.ErrNotFound
 		}
-		return entity.Bid{}, fmt.Errorf("BidRepo - GetById - r.Cluster.QueryRow: %v", err)
+		return entity.Bid{}, fmt.Errorf("BidRepo - GetById - r.Conn.QueryRow: %w", err)
 	}
 	return output, nil
 }
 
-// GetMyPagination change username на user id
-func (r *BidRepo) GetMyPagination(ctx context.Context, limit, offset int, authorId string) ([]entity.Bid, error) {
-	if limit > maxPaginationLimit {
-		limit = maxPaginationLimit
-	}
-	if limit == 0 {
-		limit = defaultPaginationLimit
+// ExistsByAuthor есть ли у автора authorId предложение на тендер tenderId
+func (r *BidRepo) ExistsByAuthor(ctx context.Context, tenderId, authorId string) (bool, error) {
+	sql, args, _ := r.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From(bidTable).
+		Where("tender_id = ? AND author_id = ?", tenderId, authorId).
+		Suffix(")").
+		ToSql()
+
+	var exists bool
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("BidRepo - ExistsByAuthor - r.Conn.QueryRow: %w", err)
 	}
+	return exists, nil
+}
 
-	orderBySql := "name"
-	sql, args, err := r.Builder.
-		Select("*").
+// GetMyPagination предложения, автором которых является пользователь authorId
+// или организации, за которые он отвечает
+func (r *BidRepo) GetMyPagination(ctx context.Context, page entity.Pagination, authorId string) (
+	entity.Page[entity.Bid], error,
+) {
+	query := r.Builder.
+		Select(bidColumns...).
 		From(bidTable).
-		Where("author_id = ?", authorId).
-		OrderBy(orderBySql).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
-		ToSql()
+		Where(authoredBy("", authorId))
+
+	query, err := keyset(query, page, bidOrder(""), "id")
 	if err != nil {
-		return nil, fmt.Errorf("BidRepo - GetByTenderID - r.Builder: %v", err)
+		return entity.Page[entity.Bid]{}, fmt.Errorf("BidRepo - GetMyPagination - keyset: %w", err)
 	}
 
-	var output []entity.Bid
-	rows, err := r.Cluster.Query(ctx, sql, args...)
-	defer rows.Close()
+	sql, args, err := query.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("BidRepo - GetByTenderID - r.Cluster.Query: %v", err)
+		return entity.Page[entity.Bid]{}, fmt.Errorf("BidRepo - GetMyPagination - r.Builder: %w", err)
 	}
-	for rows.Next() {
-		var t entity.Bid
-		if err = rows.Scan(
-			&t.Id,
-			&t.Name,
-			&t.Description,
-			&t.Status,
-			&t.TenderId,
-			&t.AuthorType,
-			&t.AuthorId,
-			&t.Version,
-			&t.CreatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("BidRepo - GetByTenderID - rows.Scan: %v", err)
-		}
-		output = append(output, t)
+
+	output, err := r.queryBids(ctx, sql, args)
+	if err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("BidRepo - GetMyPagination - %w", err)
 	}
 
-	return output, nil
+	return newPage(output, page, bidCursor), nil
 }
 
-// GetByTenderID change username на user id
-// /bids/{tenderId}/list
-func (r *BidRepo) GetByTenderID(ctx context.Context, limit, offset int, authorId, tenderId string) (
-	[]entity.Bid, error,
+// bidVisibleTo условие видимости предложения b тендера t пользователю userId:
+// автор (или ответственный за организацию-автора) видит свои предложения в любом статусе, ответственные за организацию тендера -
+// все предложения кроме черновиков и отмененных
+func bidVisibleTo(userId string) squirrel.Sqlizer {
+	return squirrel.Or{
+		authoredBy("b.", userId),
+		squirrel.And{
+			squirrel.NotEq{"b.status": []string{bidStatusCreated, bidStatusCanceled}},
+			squirrel.Expr(
+				"t.organization_id IN ("+responsibleOrgIds+")",
+				userId,
+			),
+		},
+	}
+}
+
+// GetByTenderID предложения тендера, видимые пользователю userId
+func (r *BidRepo) GetByTenderID(ctx context.Context, page entity.Pagination, userId, tenderId string) (
+	entity.Page[entity.Bid], error,
 ) {
-	if limit > maxPaginationLimit {
-		limit = maxPaginationLimit
+	query := r.Builder.
+		Select(
+			"b.id", "b.name", "b.description", "b.status", "b.tender_id",
+			"b.author_type", "b.author_id", "b.version", "b.created_at",
+		).
+		From(bidTable+" b").
+		Join(tender+" t ON t.id = b.tender_id").
+		Where("b.tender_id = ?", tenderId).
+		Where(bidVisibleTo(userId))
+
+	query, err := keyset(query, page, bidOrder("b."), "b.id")
+	if err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("BidRepo - GetByTenderID - keyset: %w", err)
 	}
-	if limit == 0 {
-		limit = defaultPaginationLimit
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("BidRepo - GetByTenderID - r.Builder: %w", err)
 	}
 
-	orderBySql := "name"
-	sql, args, err := r.Builder.
-		Select("*").
-		From(bidTable).
-		Where("author_id = ?", authorId).
-		Where("tender_id = ?", tenderId).
-		OrderBy(orderBySql).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
+	output, err := r.queryBids(ctx, sql, args)
+	if err != nil {
+		return entity.Page[entity.Bid]{}, fmt.Errorf("BidRepo - GetByTenderID - %w", err)
+	}
+
+	return newPage(output, page, bidCursor), nil
+}
+
+// Search видимые пользователю предложения, найденные по названию и описанию, по убыванию релевантности
+func (r *BidRepo) Search(ctx context.Context, page entity.Pagination, language, q, userId string) (
+	[]entity.Bid, error,
+) {
+	query, err := fullText(
+		r.Builder.
+			Select(
+				"b.id", "b.name", "b.description", "b.status", "b.tender_id",
+				"b.author_type", "b.author_id", "b.version", "b.created_at",
+			).
+			From(bidTable+" b").
+			Join(tender+" t ON t.id = b.tender_id").
+			Where(bidVisibleTo(userId)),
+		"b.", language, q,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("BidRepo - Search - fullText: %w", err)
+	}
+
+	sql, args, err := query.
+		Limit(uint64(normalizeLimit(page.Limit))).
+		Offset(uint64(page.Offset)).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("BidRepo - GetByTenderID - r.Builder: %v", err)
+		return nil, fmt.Errorf("BidRepo - Search - r.Builder: %w", err)
 	}
 
-	var output []entity.Bid
-	rows, err := r.Cluster.Query(ctx, sql, args...)
-	defer rows.Close()
+	output, err := r.queryBids(ctx, sql, args)
+	if err != nil {
+		return nil, fmt.Errorf("BidRepo - Search - %w", err)
+	}
+	return output, nil
+}
+
+// queryBids выполняет выборку колонок предложения в порядке bidColumns
+func (r *BidRepo) queryBids(ctx context.Context, sql string, args []interface{}) ([]entity.Bid, error) {
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
-		return nil, fmt.Errorf("BidRepo - GetByTenderID - r.Cluster.Query: %v", err)
+		return nil, fmt.Errorf("r.Conn.Query: %w", err)
 	}
+	defer rows.Close()
+
+	var output []entity.Bid
 	for rows.Next() {
 		var t entity.Bid
 		if err = rows.Scan(
@@ -190,10 +290,13 @@ func (r *BidRepo) GetByTenderID(ctx context.Context, limit, offset int, authorId
 			&t.Version,
 			&t.CreatedAt,
 		); err != nil {
-			return nil, fmt.Errorf("BidRepo - GetByTenderID - rows.Scan: %v", err)
+			return nil, fmt.Errorf("rows.Scan: %w", err)
 		}
 		output = append(output, t)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
 
 	return output, nil
 }
@@ -208,7 +311,7 @@ func (r *BidRepo) GetSqlData(bidId, column, field string) (SqlData, error) {
 		Where("id = ?", bidId).
 		ToSql()
 	if err != nil {
-		return SqlData{}, fmt.Errorf("TenderRepo.GetSqlData - r.Builder: %v", err)
+		return SqlData{}, fmt.Errorf("BidRepo.GetSqlData - r.Builder: %w", err)
 	}
 
 	return SqlData{
@@ -218,159 +321,223 @@ func (r *BidRepo) GetSqlData(bidId, column, field string) (SqlData, error) {
 }
 
 func (r *BidRepo) PutStatus(ctx context.Context, bidId, status string) error {
-	var (
-		err error
-		tx  pgx.Tx
-	)
-	tx, err = r.Cluster.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("BidRepo.PutStatus - r.Cluster.Begin: %v", err)
-	}
-	defer func() { _ = tx.Rollback(ctx) }()
-
 	statusSql, err := r.GetSqlData(bidId, "status", status)
-
-	sql, args, err := r.
-		Builder.
-		Update(bidTable).
-		Set("status", status).
-		Where("id = ?", bidId).
-		ToSql()
-
-	_, err = tx.Exec(ctx, statusSql.Sql, statusSql.Args...)
 	if err != nil {
-		return fmt.Errorf("BidRepo.PutStatus - tx.Exec.statusSql: %v", err)
-	}
-	_, err = tx.Exec(ctx, sql, args...)
-	if err != nil {
-		return fmt.Errorf("BidRepo.PutStatus - tx.Exec.version: %v", err)
+		return fmt.Errorf("BidRepo.PutStatus - r.GetSqlData: %w", err)
 	}
 
-	err = tx.Commit(ctx)
+	_, err = r.Conn(ctx).Exec(ctx, statusSql.Sql, statusSql.Args...)
 	if err != nil {
-		return fmt.Errorf("BidRepo.PutStatus - tx.Commit: %v", err)
+		return fmt.Errorf("BidRepo.PutStatus - r.Conn.Exec.statusSql: %w", err)
 	}
 	return nil
 }
 
 func (r *BidRepo) EditBid(ctx context.Context, input entity.Bid, bidId string) error {
-	var err error
+	// пустые поля не меняются, непустые обновляются одним запросом
+	fields := make(map[string]any)
+	if input.Name != "" {
+		fields["name"] = input.Name
+	}
+	if input.Description != "" {
+		fields["description"] = input.Description
+	}
 
-	type inputShort struct {
-		Name        string
-		Description string
+	if len(fields) == 0 {
+		return nil
 	}
-	val := reflect.ValueOf(
-		inputShort{
-			Name:        input.Name,
-			Description: input.Description,
-		},
-	)
-	typ := val.Type()
 
-	for i := 0; i < val.NumField(); i++ {
+	sql, args, err := r.Builder.Update(bidTable).SetMap(fields).Where("id = ?", bidId).ToSql()
+	if err != nil {
+		return fmt.Errorf("BidRepo.EditBid - r.Builder: %w", err)
+	}
 
-		var (
-			tx   pgx.Tx
-			sql  string
-			args []interface{}
-		)
+	if _, err = r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("BidRepo.EditBid - r.Conn.Exec: %w", err)
+	}
+	return nil
+}
 
-		field := val.Field(i)
-		fieldType := typ.Field(i)
+// IncrementVersion увеличивает версию и сохраняет снимок в историю. При expectedVersion > 0
+// текущая версия должна с ней совпасть, иначе *repoerrs.VersionConflictError
+func (r *BidRepo) IncrementVersion(ctx context.Context, bidId string, expectedVersion int) error {
+	update := r.
+		Builder.
+		Update(bidTable).
+		Set("version", squirrel.Expr("version + 1")).
+		Where("id = ?", bidId)
+	if expectedVersion > 0 {
+		update = update.Where("version = ?", expectedVersion)
+	}
 
-		if len(field.String()) == 0 || len(fieldType.Name) == 0 {
-			continue
-		}
+	sql, args, err := update.ToSql()
+	if err != nil {
+		return fmt.Errorf("BidRepo.IncrementVersion - r.Builder: %w", err)
+	}
 
-		fmt.Println("Tx Begin")
-		tx, err = r.Cluster.Begin(ctx)
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("BidRepo.IncrementVersion - r.Conn.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		current, err := r.GetById(ctx, bidId)
 		if err != nil {
-			return fmt.Errorf("TenderRepo.EditBid - r.Cluster.Begin: %v", err)
-		}
-
-		fmt.Println("Switch")
-		var column string
-		switch fieldType.Name {
-		case "Name":
-			column = "name"
-		case "Description":
-			column = "description"
-		default:
-			break
+			return err
 		}
+		return &repoerrs.VersionConflictError{CurrentVersion: current.Version}
+	}
 
-		fieldVal := fmt.Sprintf("%s", field.String())
+	if err = r.saveHistory(ctx, bidId); err != nil {
+		return fmt.Errorf("BidRepo.IncrementVersion - r.saveHistory: %w", err)
+	}
+	return nil
+}
 
-		if len(fieldVal) == 0 {
-			continue
-		}
+// setStatus меняет статус предложения, инкрементирует версию и сохраняет снимок
+func (r *BidRepo) setStatus(ctx context.Context, bidId, status string) error {
+	sql, args, err := r.
+		Builder.
+		Update(bidTable).
+		Set("status", status).
+		Set("version", squirrel.Expr("version + 1")).
+		Where("id = ?", bidId).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("BidRepo.setStatus - r.Builder: %w", err)
+	}
 
-		sql, args, err = r.
-			Builder.
-			Update(bidTable).
-			Set(column, fieldVal).
-			Where("id = ?", bidId).
-			ToSql()
+	if _, err = r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("BidRepo.setStatus - r.Conn.Exec: %w", err)
+	}
 
-		if err != nil {
-			_ = tx.Rollback(ctx)
-			return fmt.Errorf("TenderRepo.EditBid - r.Builder: %v", err)
-		}
+	return r.saveHistory(ctx, bidId)
+}
 
-		_, err = tx.Exec(ctx, sql, args...)
-		if err != nil {
-			_ = tx.Rollback(ctx)
-			return fmt.Errorf("TenderRepo.EditBid - tx.Exec: %v - %s - %s - %s", err, sql, column, fieldType.Name)
-		}
+// saveHistory сохраняет снимок текущего состояния предложения в bid_history
+func (r *BidRepo) saveHistory(ctx context.Context, bidId string) error {
+	sql, args, err := r.Builder.
+		Insert(bidHistoryTable).
+		Columns("bid_id", "name", "description", "status", "version").
+		Select(
+			r.Builder.
+				Select("id", "name", "description", "status", "version").
+				From(bidTable).
+				Where("id = ?", bidId),
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("BidRepo.saveHistory - r.Builder: %w", err)
+	}
 
-		err = tx.Commit(ctx)
-		if err != nil {
-			_ = tx.Rollback(ctx)
-			return fmt.Errorf("TenderRepo.EditBid - tx.Commit: %v", err)
-		}
+	if _, err = r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("BidRepo.saveHistory - r.Conn.Exec: %w", err)
 	}
 	return nil
 }
 
-func (r *BidRepo) IncrementVersion(ctx context.Context, bidId string) error {
-	var (
-		err error
-		t   entity.Bid
-		tx  pgx.Tx
+func (r *BidRepo) GetVersion(ctx context.Context, bidId string, version int) (entity.Bid, error) {
+	sql, args, _ := r.Builder.
+		Select(
+			"h.bid_id", "h.name", "h.description", "h.status", "b.tender_id",
+			"b.author_type", "b.author_id", "h.version", "b.created_at",
+		).
+		From(bidHistoryTable+" h").
+		Join(bidTable+" b ON b.id = h.bid_id").
+		Where("h.bid_id = ? AND h.version = ?", bidId, version).
+		ToSql()
+
+	var output entity.Bid
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&output.Id,
+		&output.Name,
+		&output.Description,
+		&output.Status,
+		&output.TenderId,
+		&output.AuthorType,
+		&output.AuthorId,
+		&output.Version,
+		&output.CreatedAt,
 	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Bid{}, repoerrs.ErrNotFound
+		}
+		return entity.Bid{}, fmt.Errorf("BidRepo - GetVersion - r.Conn.QueryRow: %w", err)
+	}
+	return output, nil
+}
 
-	t, err = r.GetById(ctx, bidId)
+func (r *BidRepo) GetVersions(ctx context.Context, bidId string) ([]entity.Bid, error) {
+	sql, args, err := r.Builder.
+		Select(
+			"h.bid_id", "h.name", "h.description", "h.status", "b.tender_id",
+			"b.author_type", "b.author_id", "h.version", "b.created_at",
+		).
+		From(bidHistoryTable+" h").
+		Join(bidTable+" b ON b.id = h.bid_id").
+		Where("h.bid_id = ?", bidId).
+		OrderBy("h.version").
+		ToSql()
 	if err != nil {
-		return fmt.Errorf("BidRepo.IncrementVersion - r.GetById: %v", err)
+		return nil, fmt.Errorf("BidRepo - GetVersions - r.Builder: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("BidRepo - GetVersions - r.Conn.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var output []entity.Bid
+	for rows.Next() {
+		var t entity.Bid
+		if err = rows.Scan(
+			&t.Id,
+			&t.Name,
+			&t.Description,
+			&t.Status,
+			&t.TenderId,
+			&t.AuthorType,
+			&t.AuthorId,
+			&t.Version,
+			&t.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("BidRepo - GetVersions - rows.Scan: %w", err)
+		}
+		output = append(output, t)
 	}
 
-	tx, err = r.Cluster.Begin(ctx)
+	return output, nil
+}
+
+// Rollback восстанавливает название и описание предложения из снимка version как новую версию
+func (r *BidRepo) Rollback(ctx context.Context, bidId string, version int) error {
+	snapshot, err := r.GetVersion(ctx, bidId, version)
 	if err != nil {
-		return fmt.Errorf("BidRepo.IncrementVersion - r.Cluster.Begin: %v", err)
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("BidRepo.Rollback - r.GetVersion: %w", err)
 	}
 
 	sql, args, err := r.
 		Builder.
 		Update(bidTable).
-		Set("version", t.Version+1).
-		Where("id = ?", t.Id).
+		Set("name", snapshot.Name).
+		Set("description", snapshot.Description).
+		Set("version", squirrel.Expr("version + 1")).
+		Where("id = ?", bidId).
 		ToSql()
 	if err != nil {
-		_ = tx.Rollback(ctx)
-		return fmt.Errorf("BidRepo.IncrementVersion - tx.Exec: %v", err)
+		return fmt.Errorf("BidRepo.Rollback - r.Builder: %w", err)
 	}
 
-	_, err = tx.Exec(ctx, sql, args...)
-	if err != nil {
-		_ = tx.Rollback(ctx)
-		return fmt.Errorf("BidRepo.IncrementVersion - tx.Exec: %v", err)
+	if _, err = r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("BidRepo.Rollback - r.Conn.Exec: %w", err)
 	}
 
-	err = tx.Commit(ctx)
-	if err != nil {
-		_ = tx.Rollback(ctx)
-		return fmt.Errorf("BidRepo.IncrementVersion - tx.Commit: %v", err)
+	if err = r.saveHistory(ctx, bidId); err != nil {
+		return fmt.Errorf("BidRepo.Rollback - r.saveHistory: %w", err)
 	}
 	return nil
 }
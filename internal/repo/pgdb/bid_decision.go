@@ -0,0 +1,132 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+	"tender-service/pkg/postgres"
+)
+
+const (
+	bidDecisionTable = "bid_decision"
+
+	bidDecisionApproved = "Approved"
+	bidDecisionRejected = "Rejected"
+)
+
+type BidDecisionRepo struct {
+	*postgres.Database
+	tenders *TenderRepo
+	bids    *BidRepo
+}
+
+func NewBidDecisionRepo(db *postgres.Database) *BidDecisionRepo {
+	return &BidDecisionRepo{Database: db, tenders: NewTenderRepo(db), bids: NewBidRepo(db)}
+}
+
+// Submit сохраняет решение ответственного и применяет его: одно отклонение отклоняет предложение,
+// набор кворума одобряет предложение и закрывает тендер.
+// Кворум равен min(quorumLimit, количество ответственных за организацию тендера).
+// Собственной транзакции не открывает, атомарность обеспечивает TxManager.Do вызывающего.
+func (r *BidDecisionRepo) Submit(ctx context.Context, input entity.BidDecision, quorumLimit int) error {
+	conn := r.Conn(ctx)
+
+	sql, args, _ := r.Builder.
+		Select("b.status", "t.id", "t.status", "t.organization_id").
+		From(bidTable+" b").
+		Join(tender+" t ON t.id = b.tender_id").
+		Where("b.id = ?", input.BidId).
+		Suffix("FOR UPDATE OF b, t").
+		ToSql()
+
+	var bidStatus, tenderId, tenderStatus, organizationId string
+	err := conn.QueryRow(ctx, sql, args...).Scan(&bidStatus, &tenderId, &tenderStatus, &organizationId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("BidDecisionRepo.Submit - conn.QueryRow.bid: %w", err)
+	}
+	if bidStatus != bidStatusPublished || tenderStatus != tenderStatusPublished {
+		return repoerrs.ErrInvalidStatus
+	}
+
+	sql, args, _ = r.Builder.Insert(bidDecisionTable).Columns(
+		"bid_id",
+		"user_id",
+		"decision",
+	).Values(
+		input.BidId,
+		input.UserId,
+		input.Decision,
+	).ToSql()
+
+	if _, err = conn.Exec(ctx, sql, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == pgerrcode.UniqueViolation {
+				return repoerrs.ErrAlreadyExists
+			}
+		}
+		return fmt.Errorf("BidDecisionRepo.Submit - conn.Exec.decision: %w", err)
+	}
+
+	switch input.Decision {
+	case bidDecisionRejected:
+		if err = r.bids.setStatus(ctx, input.BidId, bidStatusRejected); err != nil {
+			return fmt.Errorf("BidDecisionRepo.Submit - r.bids.setStatus: %w", err)
+		}
+		err = saveEvent(ctx, conn, r.Builder, entity.EventBidRejected, input.BidId, entity.BidDecidedPayload{
+			BidId:    input.BidId,
+			TenderId: tenderId,
+			Decision: bidDecisionRejected,
+		})
+		if err != nil {
+			return fmt.Errorf("BidDecisionRepo.Submit - saveEvent: %w", err)
+		}
+	case bidDecisionApproved:
+		var approvals, responsibles int
+
+		sql, args, _ = r.Builder.
+			Select("count(*)").
+			From(bidDecisionTable).
+			Where("bid_id = ? AND decision = ?", input.BidId, bidDecisionApproved).
+			ToSql()
+		if err = conn.QueryRow(ctx, sql, args...).Scan(&approvals); err != nil {
+			return fmt.Errorf("BidDecisionRepo.Submit - conn.QueryRow.approvals: %w", err)
+		}
+
+		sql, args, _ = r.Builder.
+			Select("count(*)").
+			From(orgResponsible).
+			Where("organization_id = ?", organizationId).
+			ToSql()
+		if err = conn.QueryRow(ctx, sql, args...).Scan(&responsibles); err != nil {
+			return fmt.Errorf("BidDecisionRepo.Submit - conn.QueryRow.responsibles: %w", err)
+		}
+
+		if approvals >= min(quorumLimit, responsibles) {
+			if err = r.bids.setStatus(ctx, input.BidId, bidStatusApproved); err != nil {
+				return fmt.Errorf("BidDecisionRepo.Submit - r.bids.setStatus: %w", err)
+			}
+			err = saveEvent(ctx, conn, r.Builder, entity.EventBidApproved, input.BidId, entity.BidDecidedPayload{
+				BidId:    input.BidId,
+				TenderId: tenderId,
+				Decision: bidDecisionApproved,
+			})
+			if err != nil {
+				return fmt.Errorf("BidDecisionRepo.Submit - saveEvent: %w", err)
+			}
+			if err = r.tenders.setStatus(ctx, tenderId, tenderStatusClosed); err != nil {
+				return fmt.Errorf("BidDecisionRepo.Submit - r.tenders.setStatus: %w", err)
+			}
+		}
+	}
+	return nil
+}
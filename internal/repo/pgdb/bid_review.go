@@ -0,0 +1,117 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+	"tender-service/pkg/postgres"
+)
+
+const (
+	bidReviewTable = "bid_review"
+)
+
+type BidReviewRepo struct {
+	*postgres.Database
+}
+
+func NewBidReviewRepo(db *postgres.Database) *BidReviewRepo {
+	return &BidReviewRepo{db}
+}
+
+func (r *BidReviewRepo) Create(ctx context.Context, input entity.BidReview) (entity.BidReview, error) {
+	sql, args, _ := r.Builder.Insert(bidReviewTable).Columns(
+		"description",
+		"bid_id",
+		"user_id",
+		"organization_id",
+	).Values(
+		input.Description,
+		input.BidId,
+		input.UserId,
+		input.OrganizationId,
+	).Suffix("RETURNING id, description, bid_id, created_at, user_id, organization_id").ToSql()
+
+	var output entity.BidReview
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&output.Id,
+		&output.Description,
+		&output.BidId,
+		&output.CreatedAt,
+		&output.UserId,
+		&output.OrganizationId,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == pgerrcode.UniqueViolation {
+				return entity.BidReview{}, repoerrs.ErrAlreadyExists
+			}
+		}
+		return entity.BidReview{}, fmt.Errorf("BidReviewRepo - Create - r.Conn.QueryRow: %w", err)
+	}
+	return output, nil
+}
+
+// GetByAuthorPagination отзывы на предложения автора по всем тендерам,
+// organizationId опционально ограничивает отзывы одной организацией
+func (r *BidReviewRepo) GetByAuthorPagination(
+	ctx context.Context, limit, offset int, authorId, organizationId string,
+) ([]entity.BidReview, error) {
+	if limit > maxPaginationLimit {
+		limit = maxPaginationLimit
+	}
+	if limit == 0 {
+		limit = defaultPaginationLimit
+	}
+
+	builder := r.Builder.
+		Select(
+			"r.id", "r.description", "r.bid_id", "r.created_at",
+			"COALESCE(r.user_id::text, '')", "COALESCE(r.organization_id::text, '')",
+		).
+		From(bidReviewTable+" r").
+		Join(bidTable+" b ON b.id = r.bid_id").
+		Where("b.author_id = ?", authorId)
+	if organizationId != "" {
+		builder = builder.Where("r.organization_id = ?", organizationId)
+	}
+
+	sql, args, err := builder.
+		OrderBy("r.created_at DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("BidReviewRepo - GetByAuthorPagination - r.Builder: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("BidReviewRepo - GetByAuthorPagination - r.Conn.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var output []entity.BidReview
+	for rows.Next() {
+		var t entity.BidReview
+		if err = rows.Scan(
+			&t.Id,
+			&t.Description,
+			&t.BidId,
+			&t.CreatedAt,
+			&t.UserId,
+			&t.OrganizationId,
+		); err != nil {
+			return nil, fmt.Errorf("BidReviewRepo - GetByAuthorPagination - rows.Scan: %w", err)
+		}
+		output = append(output, t)
+	}
+
+	return output, nil
+}
@@ -5,9 +5,7 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
 	"tender-service/internal/entity"
 	"tender-service/internal/repo/repoerrs"
 	"tender-service/pkg/postgres"
@@ -15,6 +13,11 @@ import (
 
 const (
 	orgResponsible = "organization_responsible"
+
+	// responsibleOrgIds подзапрос организаций, за которые отвечает пользователь (параметр - его id);
+	// у удаленной организации ответственных нет, как в GetByIds
+	responsibleOrgIds = "SELECT r.organization_id FROM " + orgResponsible + " r JOIN " + organization +
+		" o ON o.id = r.organization_id AND o.deleted_at IS NULL WHERE r.user_id = ?"
 )
 
 type OrgResponsibleRepo struct {
@@ -25,33 +28,48 @@ func NewOrgResponsibleRepo(db *postgres.Database) *OrgResponsibleRepo {
 	return &OrgResponsibleRepo{db}
 }
 
+// Create назначает ответственного. Пользователь отвечает только за одну организацию (UNIQUE (user_id)):
+// повтор той же пары - ErrAlreadyExists, ответственный за другую организацию - ErrConflict
 func (r *OrgResponsibleRepo) Create(
 	ctx context.Context, input entity.OrgResponsible,
 ) (entity.OrgResponsible, error) {
+	// ON CONFLICT вместо ошибки уникальности: ошибка прервала бы внешнюю транзакцию до проверки существующей связи
 	sql, args, _ := r.Builder.Insert(orgResponsible).Columns(
 		"organization_id",
 		"user_id",
 	).Values(
 		input.OrganizationId,
 		input.UserId,
-	).Suffix("RETURNING id, organization_id, user_id").ToSql()
+	).Suffix("ON CONFLICT (user_id) DO NOTHING RETURNING id, organization_id, user_id").ToSql()
 
 	var output entity.OrgResponsible
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&output.Id,
 		&output.OrganizationId,
 		&output.UserId,
 	)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if ok := errors.As(err, &pgErr); ok {
-			if pgErr.Code == pgerrcode.UniqueViolation {
-				return entity.OrgResponsible{}, repoerrs.ErrAlreadyExists
-			}
-		}
-		return entity.OrgResponsible{}, fmt.Errorf("OrgResponsibleRepo - Create - r.Cluster.QueryRow: %v", err)
+	if err == nil {
+		return output, nil
 	}
-	return output, nil
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return entity.OrgResponsible{}, fmt.Errorf("OrgResponsibleRepo - Create - r.Conn.QueryRow: %w", err)
+	}
+
+	sql, args, _ = r.Builder.
+		Select("organization_id").
+		From(orgResponsible).
+		Where("user_id = ?", input.UserId).
+		ToSql()
+
+	var organizationId string
+	if err = r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&organizationId); err != nil {
+		return entity.OrgResponsible{}, fmt.Errorf("OrgResponsibleRepo - Create - r.Conn.QueryRow.existing: %w", err)
+	}
+	if organizationId == input.OrganizationId {
+		return entity.OrgResponsible{}, repoerrs.ErrAlreadyExists
+	}
+	// пользователь уже ответственный в другой организации
+	return entity.OrgResponsible{}, repoerrs.ErrConflict
 }
 
 func (r *OrgResponsibleRepo) GetById(ctx context.Context, id string) (entity.OrgResponsible, error) {
@@ -62,7 +80,7 @@ func (r *OrgResponsibleRepo) GetById(ctx context.Context, id string) (entity.Org
 		ToSql()
 
 	var output entity.OrgResponsible
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&output.Id,
 		&output.OrganizationId,
 		&output.UserId,
@@ -71,22 +89,24 @@ func (r *OrgResponsibleRepo) GetById(ctx context.Context, id string) (entity.Org
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.OrgResponsible{}, repoerrs.ErrNotFound
 		}
-		return entity.OrgResponsible{}, fmt.Errorf("OrgResponsibleRepo - GetById - r.Cluster.QueryRow: %v", err)
+		return entity.OrgResponsible{}, fmt.Errorf("OrgResponsibleRepo - GetById - r.Conn.QueryRow: %w", err)
 	}
 
 	return output, nil
 }
 
+// GetByIds ответственный за неудаленную организацию; за удаленную - ErrNotFound
 func (r *OrgResponsibleRepo) GetByIds(ctx context.Context, input entity.OrgResponsible) (
 	entity.OrgResponsible, error,
 ) {
 	sql, args, _ := r.Builder.
-		Select("*").
-		From(orgResponsible).
-		Where("organization_id = ? and user_id = ?", input.OrganizationId, input.UserId).ToSql()
+		Select("r.id", "r.organization_id", "r.user_id").
+		From(orgResponsible+" r").
+		Join(organization+" o ON o.id = r.organization_id AND o.deleted_at IS NULL").
+		Where("r.organization_id = ? and r.user_id = ?", input.OrganizationId, input.UserId).ToSql()
 
 	var output entity.OrgResponsible
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&output.Id,
 		&output.OrganizationId,
 		&output.UserId,
@@ -95,8 +115,69 @@ func (r *OrgResponsibleRepo) GetByIds(ctx context.Context, input entity.OrgRespo
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.OrgResponsible{}, repoerrs.ErrNotFound
 		}
-		return entity.OrgResponsible{}, fmt.Errorf("OrgResponsibleRepo - GetByIds - r.Cluster.QueryRow: %v", err)
+		return entity.OrgResponsible{}, fmt.Errorf("OrgResponsibleRepo - GetByIds - r.Conn.QueryRow: %w", err)
 	}
 
 	return output, nil
 }
+
+// GetByOrganizationPagination ответственные за организацию
+func (r *OrgResponsibleRepo) GetByOrganizationPagination(
+	ctx context.Context, limit, offset int, organizationId string,
+) ([]entity.OrgResponsible, error) {
+	if limit > maxPaginationLimit {
+		limit = maxPaginationLimit
+	}
+	if limit == 0 {
+		limit = defaultPaginationLimit
+	}
+
+	sql, args, err := r.Builder.
+		Select("id", "organization_id", "user_id").
+		From(orgResponsible).
+		Where("organization_id = ?", organizationId).
+		OrderBy("id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("OrgResponsibleRepo - GetByOrganizationPagination - r.Builder: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("OrgResponsibleRepo - GetByOrganizationPagination - r.Conn.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var output []entity.OrgResponsible
+	for rows.Next() {
+		var o entity.OrgResponsible
+		if err = rows.Scan(&o.Id, &o.OrganizationId, &o.UserId); err != nil {
+			return nil, fmt.Errorf("OrgResponsibleRepo - GetByOrganizationPagination - rows.Scan: %w", err)
+		}
+		output = append(output, o)
+	}
+
+	return output, nil
+}
+
+// Delete снимает с пользователя ответственность за организацию
+func (r *OrgResponsibleRepo) Delete(ctx context.Context, input entity.OrgResponsible) error {
+	sql, args, err := r.Builder.
+		Delete(orgResponsible).
+		Where("organization_id = ? AND user_id = ?", input.OrganizationId, input.UserId).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("OrgResponsibleRepo - Delete - r.Builder: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("OrgResponsibleRepo - Delete - r.Conn.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -17,6 +19,18 @@ const (
 	organization = "organization"
 )
 
+// organizationColumns колонки организации в порядке полей entity.Organization
+var organizationColumns = []string{
+	"id", "name", "description", "type", "created_at", "updated_at", "deleted_at",
+}
+
+// likeEscaper экранирует спецсимволы шаблона LIKE в пользовательском вводе
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
 type OrganizationRepo struct {
 	*postgres.Database
 }
@@ -34,16 +48,17 @@ func (r *OrganizationRepo) Create(ctx context.Context, input entity.Organization
 		input.Name,
 		input.Description,
 		input.OrganizationType,
-	).Suffix("RETURNING id, name, description, type, created_at, updated_at").ToSql()
+	).Suffix("RETURNING id, name, description, type, created_at, updated_at, deleted_at").ToSql()
 
 	var output entity.Organization
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&output.Id,
 		&output.Name,
 		&output.Description,
 		&output.OrganizationType,
 		&output.CreatedAt,
 		&output.UpdatedAt,
+		&output.DeletedAt,
 	)
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -52,33 +67,155 @@ func (r *OrganizationRepo) Create(ctx context.Context, input entity.Organization
 				return entity.Organization{}, repoerrs.ErrAlreadyExists
 			}
 		}
-		return entity.Organization{}, fmt.Errorf("OrganizationRepo - Create - r.Cluster.QueryRow: %v", err)
+		return entity.Organization{}, fmt.Errorf("OrganizationRepo - Create - r.Conn.QueryRow: %w", err)
 	}
 	return output, nil
 }
 
+// GetById удаленные организации не возвращаются
 func (r *OrganizationRepo) GetById(ctx context.Context, id string) (entity.Organization, error) {
 	sql, args, _ := r.Builder.
-		Select("*").
+		Select(organizationColumns...).
 		From(organization).
-		Where("id = ?", id).
+		Where("id = ? AND deleted_at IS NULL", id).
 		ToSql()
 
 	var output entity.Organization
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&output.Id,
 		&output.Name,
 		&output.Description,
 		&output.OrganizationType,
 		&output.CreatedAt,
 		&output.UpdatedAt,
+		&output.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Organization{}, repoerrs.ErrNotFound
 		}
-		return entity.Organization{}, fmt.Errorf("OrganizationRepo - GetById - r.Cluster.QueryRow: %v", err)
+		return entity.Organization{}, fmt.Errorf("OrganizationRepo - GetById - r.Conn.QueryRow: %w", err)
 	}
 
 	return output, nil
 }
+
+// GetPagination список неудаленных организаций, name - поиск по подстроке названия
+func (r *OrganizationRepo) GetPagination(ctx context.Context, limit, offset int, name string) (
+	[]entity.Organization, error,
+) {
+	if limit > maxPaginationLimit {
+		limit = maxPaginationLimit
+	}
+	if limit == 0 {
+		limit = defaultPaginationLimit
+	}
+
+	query := r.Builder.
+		Select(organizationColumns...).
+		From(organization).
+		Where("deleted_at IS NULL")
+	if name != "" {
+		query = query.Where(squirrel.ILike{"name": "%" + escapeLike(name) + "%"})
+	}
+
+	sql, args, err := query.
+		OrderBy("name", "id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("OrganizationRepo - GetPagination - r.Builder: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("OrganizationRepo - GetPagination - r.Conn.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var output []entity.Organization
+	for rows.Next() {
+		var o entity.Organization
+		if err = rows.Scan(
+			&o.Id,
+			&o.Name,
+			&o.Description,
+			&o.OrganizationType,
+			&o.CreatedAt,
+			&o.UpdatedAt,
+			&o.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("OrganizationRepo - GetPagination - rows.Scan: %w", err)
+		}
+		output = append(output, o)
+	}
+
+	return output, nil
+}
+
+// Update меняет непустые поля организации и обновляет updated_at
+func (r *OrganizationRepo) Update(ctx context.Context, id string, input entity.Organization) (
+	entity.Organization, error,
+) {
+	query := r.Builder.
+		Update(organization).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where("id = ? AND deleted_at IS NULL", id)
+	if input.Name != "" {
+		query = query.Set("name", input.Name)
+	}
+	if input.Description != "" {
+		query = query.Set("description", input.Description)
+	}
+	if input.OrganizationType != "" {
+		query = query.Set("type", input.OrganizationType)
+	}
+
+	sql, args, err := query.
+		Suffix("RETURNING id, name, description, type, created_at, updated_at, deleted_at").
+		ToSql()
+	if err != nil {
+		return entity.Organization{}, fmt.Errorf("OrganizationRepo - Update - r.Builder: %w", err)
+	}
+
+	var output entity.Organization
+	err = r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&output.Id,
+		&output.Name,
+		&output.Description,
+		&output.OrganizationType,
+		&output.CreatedAt,
+		&output.UpdatedAt,
+		&output.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Organization{}, repoerrs.ErrNotFound
+		}
+		return entity.Organization{}, fmt.Errorf("OrganizationRepo - Update - r.Conn.QueryRow: %w", err)
+	}
+	return output, nil
+}
+
+// Delete мягкое удаление: тендеры и ответственные организации остаются в базе
+func (r *OrganizationRepo) Delete(ctx context.Context, id string) error {
+	sql, args, err := r.Builder.
+		Update(organization).
+		Set("deleted_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where("id = ? AND deleted_at IS NULL", id).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("OrganizationRepo - Delete - r.Builder: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("OrganizationRepo - Delete - r.Conn.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+	return nil
+}
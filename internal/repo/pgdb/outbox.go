@@ -0,0 +1,112 @@
+package pgdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
+	"tender-service/pkg/postgres"
+)
+
+const outboxTable = "outbox"
+
+type OutboxRepo struct {
+	*postgres.Database
+}
+
+func NewOutboxRepo(db *postgres.Database) *OutboxRepo {
+	return &OutboxRepo{db}
+}
+
+// saveEvent записывает событие в outbox через q, в транзакции изменения состояния
+func saveEvent(
+	ctx context.Context, q postgres.Querier, builder squirrel.StatementBuilderType,
+	eventType entity.EventType, aggregateId string, payload any,
+) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("saveEvent - json.Marshal: %w", err)
+	}
+
+	sql, args, err := builder.
+		Insert(outboxTable).
+		Columns("event_type", "aggregate_id", "payload").
+		Values(string(eventType), aggregateId, data).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("saveEvent - builder: %w", err)
+	}
+
+	if _, err = q.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("saveEvent - q.Exec: %w", err)
+	}
+	return nil
+}
+
+// Dispatch блокирует пачку неотправленных событий (FOR UPDATE SKIP LOCKED, поэтому
+// несколько экземпляров сервиса не доставят одно событие дважды), передает каждое в publish
+// и отмечает отправленные; при ошибке увеличивает attempts. Возвращает число отправленных событий.
+func (r *OutboxRepo) Dispatch(
+	ctx context.Context, limit, maxAttempts int, publish func(context.Context, entity.Event) error,
+) (int, error) {
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("OutboxRepo.Dispatch - r.Begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	sql, args, _ := r.Builder.
+		Select("id", "event_type", "aggregate_id", "payload", "created_at", "attempts").
+		From(outboxTable).
+		Where("published_at IS NULL AND attempts < ?", maxAttempts).
+		OrderBy("created_at", "id").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("OutboxRepo.Dispatch - tx.Query: %w", err)
+	}
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Event, error) {
+		var event entity.Event
+		err := row.Scan(
+			&event.Id,
+			&event.Type,
+			&event.AggregateId,
+			&event.Payload,
+			&event.CreatedAt,
+			&event.Attempts,
+		)
+		return event, err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("OutboxRepo.Dispatch - pgx.CollectRows: %w", err)
+	}
+
+	published := 0
+	for _, event := range events {
+		update := r.Builder.Update(outboxTable).Where("id = ?", event.Id)
+		if pubErr := publish(ctx, event); pubErr != nil {
+			update = update.
+				Set("attempts", squirrel.Expr("attempts + 1")).
+				Set("last_error", pubErr.Error())
+		} else {
+			update = update.Set("published_at", squirrel.Expr("CURRENT_TIMESTAMP"))
+			published++
+		}
+
+		sql, args, _ = update.ToSql()
+		if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			return 0, fmt.Errorf("OutboxRepo.Dispatch - tx.Exec: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("OutboxRepo.Dispatch - tx.Commit: %w", err)
+	}
+	return published, nil
+}
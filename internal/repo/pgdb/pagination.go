@@ -0,0 +1,148 @@
+package pgdb
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"tender-service/internal/entity"
+)
+
+// orderKey колонка сортировки keyset-пагинации.
+// Cast приводит строковое значение из курсора к типу колонки
+type orderKey struct {
+	Column string
+	Cast   string
+	Desc   bool
+}
+
+func normalizeLimit(limit int) int {
+	if limit > maxPaginationLimit {
+		return maxPaginationLimit
+	}
+	if limit <= 0 {
+		return defaultPaginationLimit
+	}
+	return limit
+}
+
+// keyset упорядочивает выборку по keys и idCol и ограничивает ее страницей p.
+// Запрашивается на одну строку больше лимита, чтобы понять, есть ли следующая страница
+func keyset(query squirrel.SelectBuilder, p entity.Pagination, keys []orderKey, idCol string) (
+	squirrel.SelectBuilder, error,
+) {
+	if p.After != nil {
+		if len(p.After.Keys) != len(keys) {
+			return query, fmt.Errorf("cursor has %d keys, sort has %d", len(p.After.Keys), len(keys))
+		}
+		query = query.Where(after(p.After, keys, idCol))
+	} else if p.Offset > 0 {
+		query = query.Offset(uint64(p.Offset))
+	}
+
+	orderBy := make([]string, 0, len(keys)+1)
+	for _, k := range keys {
+		if k.Desc {
+			orderBy = append(orderBy, k.Column+" DESC")
+		} else {
+			orderBy = append(orderBy, k.Column)
+		}
+	}
+	orderBy = append(orderBy, idCol)
+
+	return query.
+		OrderBy(orderBy...).
+		Limit(uint64(normalizeLimit(p.Limit) + 1)), nil
+}
+
+// after строки строго после курсора c в порядке keys, idCol:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... OR (k1 = v1 AND ... AND id > c.Id),
+// для DESC-колонок сравнение меняется на <
+func after(c *entity.Cursor, keys []orderKey, idCol string) squirrel.Sqlizer {
+	var (
+		or     squirrel.Or
+		prefix squirrel.And
+	)
+	for i, k := range keys {
+		op := " > "
+		if k.Desc {
+			op = " < "
+		}
+		or = append(or, append(append(squirrel.And{}, prefix...), squirrel.Expr(k.Column+op+"?"+k.Cast, c.Keys[i])))
+		prefix = append(prefix, squirrel.Expr(k.Column+" = ?"+k.Cast, c.Keys[i]))
+	}
+	or = append(or, append(append(squirrel.And{}, prefix...), squirrel.Expr(idCol+" > ?", c.Id)))
+	return or
+}
+
+// newPage отрезает лишнюю строку, запрошенную keyset, и строит курсор следующей страницы
+func newPage[T any](items []T, p entity.Pagination, key func(T) entity.Cursor) entity.Page[T] {
+	limit := normalizeLimit(p.Limit)
+	if len(items) <= limit {
+		return entity.Page[T]{Items: items}
+	}
+	items = items[:limit]
+	next := key(items[limit-1])
+	return entity.Page[T]{Items: items, Next: &next}
+}
+
+// bidOrder предложения всегда упорядочены по названию
+func bidOrder(alias string) []orderKey {
+	return []orderKey{{Column: alias + "name"}}
+}
+
+func bidCursor(b entity.Bid) entity.Cursor {
+	return entity.Cursor{Keys: []string{b.Name}, Id: b.Id}
+}
+
+// tenderSortColumn колонка сортировки тендеров и значение для курсора
+type tenderSortColumn struct {
+	orderKey
+	value func(entity.Tender) string
+}
+
+// tenderSortColumns allow-list сортировок списка тендеров
+var tenderSortColumns = map[string]tenderSortColumn{
+	entity.TenderSortName: {
+		orderKey: orderKey{Column: "name"},
+		value:    func(t entity.Tender) string { return t.Name },
+	},
+	entity.TenderSortCreatedAt: {
+		orderKey: orderKey{Column: "created_at", Cast: "::timestamp"},
+		value:    func(t entity.Tender) string { return t.CreatedAt.Format(time.RFC3339Nano) },
+	},
+	entity.TenderSortVersion: {
+		orderKey: orderKey{Column: "version", Cast: "::int"},
+		value:    func(t entity.Tender) string { return strconv.Itoa(t.Version) },
+	},
+}
+
+// tenderOrder колонки keyset и построитель курсора для сортировки sort (по умолчанию - по названию)
+func tenderOrder(sort []entity.SortField) ([]orderKey, func(entity.Tender) entity.Cursor, error) {
+	if len(sort) == 0 {
+		sort = []entity.SortField{{Field: entity.TenderSortName}}
+	}
+
+	keys := make([]orderKey, 0, len(sort))
+	values := make([]func(entity.Tender) string, 0, len(sort))
+	for _, f := range sort {
+		column, ok := tenderSortColumns[f.Field]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown sort field %q", f.Field)
+		}
+		key := column.orderKey
+		key.Desc = f.Desc
+		keys = append(keys, key)
+		values = append(values, column.value)
+	}
+
+	cursor := func(t entity.Tender) entity.Cursor {
+		c := entity.Cursor{Keys: make([]string, 0, len(values)), Id: t.Id}
+		for _, v := range values {
+			c.Keys = append(c.Keys, v(t))
+		}
+		return c
+	}
+	return keys, cursor, nil
+}
@@ -0,0 +1,28 @@
+package pgdb
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// searchColumns tsvector-колонки для поддерживаемых конфигураций стемминга
+var searchColumns = map[string]string{
+	"russian": "search_ru",
+	"english": "search_en",
+}
+
+// fullText условие полнотекстового поиска и сортировка по релевантности
+// для запроса пользователя query в конфигурации language
+func fullText(query squirrel.SelectBuilder, alias, language, q string) (squirrel.SelectBuilder, error) {
+	column, ok := searchColumns[language]
+	if !ok {
+		return query, fmt.Errorf("unsupported search language %q", language)
+	}
+	column = alias + column
+
+	return query.
+		Where(column+" @@ websearch_to_tsquery(?::regconfig, ?)", language, q).
+		OrderByClause("ts_rank("+column+", websearch_to_tsquery(?::regconfig, ?)) DESC", language, q).
+		OrderBy(alias + "id"), nil
+}
@@ -4,24 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
 
 	"tender-service/internal/entity"
 	"tender-service/internal/repo/repoerrs"
 	"tender-service/pkg/postgres"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
 const (
-	tender = "tender"
+	tender        = "tender"
+	tenderHistory = "tender_history"
+
+	tenderStatusPublished = "Published"
+	tenderStatusClosed    = "Closed"
 
 	maxPaginationLimit     = 50
 	defaultPaginationLimit = 5
 )
 
+// tenderColumns колонки тендера в порядке полей entity.Tender
+var tenderColumns = []string{
+	"id", "name", "description", "type", "status",
+	"organization_id", "version", "created_at", "creator_username",
+}
+
 type TenderRepo struct {
 	*postgres.Database
 }
@@ -49,7 +59,7 @@ func (r *TenderRepo) Create(ctx context.Context, input entity.Tender) (entity.Te
 	).ToSql()
 
 	var output entity.Tender
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&output.Id,
 		&output.Name,
 		&output.Description,
@@ -67,20 +77,24 @@ func (r *TenderRepo) Create(ctx context.Context, input entity.Tender) (entity.Te
 				return entity.Tender{}, repoerrs.ErrAlreadyExists
 			}
 		}
-		return entity.Tender{}, fmt.Errorf("TenderRepo - Create - r.Cluster.QueryRow: %v", err)
+		return entity.Tender{}, fmt.Errorf("TenderRepo - Create - r.Conn.QueryRow: %w", err)
+	}
+
+	if err = r.saveHistory(ctx, output.Id); err != nil {
+		return entity.Tender{}, fmt.Errorf("TenderRepo - Create - r.saveHistory: %w", err)
 	}
 	return output, nil
 }
 
 func (r *TenderRepo) GetById(ctx context.Context, id string) (entity.Tender, error) {
 	sql, args, _ := r.Builder.
-		Select("*").
+		Select(tenderColumns...).
 		From(tender).
 		Where("id = ?", id).
 		ToSql()
 
 	var output entity.Tender
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&output.Id,
 		&output.Name,
 		&output.Description,
@@ -95,126 +109,165 @@ func (r *TenderRepo) GetById(ctx context.Context, id string) (entity.Tender, err
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Tender{}, repoerrs.ErrNotFound
 		}
-		return entity.Tender{}, fmt.Errorf("TenderRepo - GetById - r.Cluster.QueryRow: %v", err)
+		return entity.Tender{}, fmt.Errorf("TenderRepo - GetById - r.Conn.QueryRow: %w", err)
 	}
 	return output, nil
 }
 
-func (r *TenderRepo) GetByTypePagination(ctx context.Context, limit, offset int, serviceType []string) (
-	[]entity.Tender, error,
+// visibleTo опубликованные тендеры видны всем, остальные - только ответственным за организацию
+func visibleTo(userId string) squirrel.Sqlizer {
+	return squirrel.Or{
+		squirrel.Eq{"status": tenderStatusPublished},
+		squirrel.Expr(
+			"organization_id IN ("+responsibleOrgIds+")", userId,
+		),
+	}
+}
+
+// GetByTypePagination видимые пользователю тендеры, подходящие под filter, одним запросом.
+// При page.WithTotal общее число строк считается оконной функцией в том же запросе
+func (r *TenderRepo) GetByTypePagination(
+	ctx context.Context, page entity.Pagination, filter entity.TenderFilter, userId string,
+) (
+	entity.Page[entity.Tender], error,
 ) {
-	type sqlData struct {
-		sql  string
-		args []interface{}
+	keys, cursor, err := tenderOrder(page.Sort)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetByTypePagination - tenderOrder: %w", err)
+	}
+
+	filtered := r.Builder.
+		Select(tenderColumns...).
+		From(tender).
+		Where(visibleTo(userId))
+	if len(filter.ServiceType) != 0 {
+		filtered = filtered.Where("type = ANY(?::service_type[])", filter.ServiceType)
+	}
+	if len(filter.Status) != 0 {
+		filtered = filtered.Where("status = ANY(?::tender_status[])", filter.Status)
+	}
+	if filter.OrganizationId != "" {
+		filtered = filtered.Where(squirrel.Eq{"organization_id": filter.OrganizationId})
 	}
+	if filter.CreatorUsername != "" {
+		filtered = filtered.Where(squirrel.Eq{"creator_username": filter.CreatorUsername})
+	}
+	if filter.CreatedAfter != nil {
+		filtered = filtered.Where(squirrel.GtOrEq{"created_at": *filter.CreatedAfter})
+	}
+	if filter.CreatedBefore != nil {
+		filtered = filtered.Where(squirrel.Lt{"created_at": *filter.CreatedBefore})
+	}
+
+	query := filtered
+	if page.WithTotal {
+		query = r.Builder.
+			Select("*").
+			FromSelect(filtered.Column("COUNT(*) OVER () AS total"), "f")
+	}
+
+	query, err = keyset(query, page, keys, "id")
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetByTypePagination - keyset: %w", err)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetByTypePagination - r.Builder: %w", err)
+	}
+
 	var (
-		sqlArray []sqlData
+		output []entity.Tender
+		total  int
 	)
-	if limit > maxPaginationLimit {
-		limit = maxPaginationLimit
+	if page.WithTotal {
+		output, total, err = r.queryTendersWithTotal(ctx, sql, args)
+	} else {
+		output, err = r.queryTenders(ctx, sql, args)
 	}
-	if limit == 0 {
-		limit = defaultPaginationLimit
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetByTypePagination - %w", err)
 	}
 
-	orderBySql := "name"
-	if len(serviceType) == 0 {
-		sql, args, err := r.Builder.
-			Select("*").
+	result := newPage(output, page, cursor)
+	// по пустой странице после курсора или смещения общее число не определить
+	if page.WithTotal && (len(output) != 0 || (page.After == nil && page.Offset == 0)) {
+		result.Total = &total
+	}
+	return result, nil
+}
+
+func (r *TenderRepo) GetMyPagination(ctx context.Context, page entity.Pagination, username string) (
+	entity.Page[entity.Tender], error,
+) {
+	keys, cursor, err := tenderOrder(page.Sort)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetMyPagination - tenderOrder: %w", err)
+	}
+
+	query, err := keyset(
+		r.Builder.
+			Select(tenderColumns...).
 			From(tender).
-			OrderBy(orderBySql).
-			Limit(uint64(limit)).
-			Offset(uint64(offset)).
-			ToSql()
-		if err != nil {
-			return nil, fmt.Errorf("TenderRepo - GetByTypePagination - r.Builder: %v", err)
-		}
-		sqlArray = append(
-			sqlArray, sqlData{
-				sql:  sql,
-				args: args,
-			},
-		)
-	} else {
-		for _, stype := range serviceType {
-			sql, args, err := r.Builder.
-				Select("*").
-				From(tender).
-				Where("type = ?", stype).
-				OrderBy(orderBySql).
-				Limit(uint64(limit)).
-				Offset(uint64(offset)).
-				ToSql()
-			if err != nil {
-				return nil, fmt.Errorf("TenderRepo - GetByTypePagination - r.Builder: %v", err)
-			}
-			sqlArray = append(
-				sqlArray, sqlData{
-					sql:  sql,
-					args: args,
-				},
-			)
-		}
+			Where("creator_username = ?", username),
+		page, keys, "id",
+	)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetMyPagination - keyset: %w", err)
 	}
 
-	var output []entity.Tender
-	for _, data := range sqlArray {
-		rows, err := r.Cluster.Query(ctx, data.sql, data.args...)
-		if err != nil {
-			return nil, fmt.Errorf("TenderRepo - GetByTypePagination - r.Cluster.Query: %v", err)
-		}
-		for rows.Next() {
-			var t entity.Tender
-			if err = rows.Scan(
-				&t.Id,
-				&t.Name,
-				&t.Description,
-				&t.ServiceType,
-				&t.Status,
-				&t.OrganizationId,
-				&t.Version,
-				&t.CreatedAt,
-				&t.CreatorUsername,
-			); err != nil {
-				return nil, fmt.Errorf("TenderRepo - GetByTypePagination - rows.Scan: %v", err)
-			}
-			output = append(output, t)
-		}
-		rows.Close()
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetMyPagination - r.Builder: %w", err)
 	}
 
-	return output, nil
+	output, err := r.queryTenders(ctx, sql, args)
+	if err != nil {
+		return entity.Page[entity.Tender]{}, fmt.Errorf("TenderRepo - GetMyPagination - %w", err)
+	}
+
+	return newPage(output, page, cursor), nil
 }
 
-func (r *TenderRepo) GetMyPagination(ctx context.Context, limit, offset int, username string) (
+// Search видимые пользователю тендеры, найденные по названию и описанию, по убыванию релевантности
+func (r *TenderRepo) Search(ctx context.Context, page entity.Pagination, language, q, userId string) (
 	[]entity.Tender, error,
 ) {
-	if limit > maxPaginationLimit {
-		limit = maxPaginationLimit
-	}
-	if limit == 0 {
-		limit = defaultPaginationLimit
+	query, err := fullText(
+		r.Builder.
+			Select(tenderColumns...).
+			From(tender).
+			Where(visibleTo(userId)),
+		"", language, q,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepo - Search - fullText: %w", err)
 	}
 
-	orderBySql := "name"
-	sql, args, err := r.Builder.
-		Select("*").
-		From(tender).
-		Where("creator_username = ?", username).
-		OrderBy(orderBySql).
-		Limit(uint64(limit)).
-		Offset(uint64(offset)).
+	sql, args, err := query.
+		Limit(uint64(normalizeLimit(page.Limit))).
+		Offset(uint64(page.Offset)).
 		ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("TenderRepo - GetByTenderID - r.Builder: %v", err)
+		return nil, fmt.Errorf("TenderRepo - Search - r.Builder: %w", err)
 	}
 
-	var output []entity.Tender
-	rows, err := r.Cluster.Query(ctx, sql, args...)
-	defer rows.Close()
+	output, err := r.queryTenders(ctx, sql, args)
 	if err != nil {
-		return nil, fmt.Errorf("TenderRepo - GetByTenderID - r.Cluster.Query: %v", err)
+		return nil, fmt.Errorf("TenderRepo - Search - %w", err)
 	}
+	return output, nil
+}
+
+// queryTenders выполняет выборку всех колонок tender
+func (r *TenderRepo) queryTenders(ctx context.Context, sql string, args []interface{}) ([]entity.Tender, error) {
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("r.Conn.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var output []entity.Tender
 	for rows.Next() {
 		var t entity.Tender
 		if err = rows.Scan(
@@ -228,14 +281,56 @@ func (r *TenderRepo) GetMyPagination(ctx context.Context, limit, offset int, use
 			&t.CreatedAt,
 			&t.CreatorUsername,
 		); err != nil {
-			return nil, fmt.Errorf("TenderRepo - GetByTenderID - rows.Scan: %v", err)
+			return nil, fmt.Errorf("rows.Scan: %w", err)
 		}
 		output = append(output, t)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
 
 	return output, nil
 }
 
+// queryTendersWithTotal как queryTenders, но с дополнительной колонкой total
+func (r *TenderRepo) queryTendersWithTotal(ctx context.Context, sql string, args []interface{}) (
+	[]entity.Tender, int, error,
+) {
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("r.Conn.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		output []entity.Tender
+		total  int
+	)
+	for rows.Next() {
+		var t entity.Tender
+		if err = rows.Scan(
+			&t.Id,
+			&t.Name,
+			&t.Description,
+			&t.ServiceType,
+			&t.Status,
+			&t.OrganizationId,
+			&t.Version,
+			&t.CreatedAt,
+			&t.CreatorUsername,
+			&total,
+		); err != nil {
+			return nil, 0, fmt.Errorf("rows.Scan: %w", err)
+		}
+		output = append(output, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return output, total, nil
+}
+
 type SqlData struct {
 	Sql  string
 	Args []interface{}
@@ -251,7 +346,7 @@ func (r *TenderRepo) GetSqlData(tenderId, column, field string) (SqlData, error)
 		Where("id = ?", tenderId).
 		ToSql()
 	if err != nil {
-		return SqlData{}, fmt.Errorf("TenderRepo.GetSqlData - r.Builder: %v", err)
+		return SqlData{}, fmt.Errorf("TenderRepo.GetSqlData - r.Builder: %w", err)
 	}
 
 	return SqlData{
@@ -261,160 +356,278 @@ func (r *TenderRepo) GetSqlData(tenderId, column, field string) (SqlData, error)
 }
 
 func (r *TenderRepo) PutStatus(ctx context.Context, tenderId, status string) error {
-	var (
-		err error
-		tx  pgx.Tx
-	)
-	tx, err = r.Cluster.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("TenderRepo.PutStatus - r.Cluster.Begin: %v", err)
-	}
-	defer func() { _ = tx.Rollback(ctx) }()
-
-	statusSql, err := r.GetSqlData(tenderId, "status", status)
-
-	sql, args, err := r.
-		Builder.
-		Update(tender).
-		Set("status", status).
+	sql, args, _ := r.Builder.
+		Select("status").
+		From(tender).
 		Where("id = ?", tenderId).
+		Suffix("FOR UPDATE").
 		ToSql()
 
-	_, err = tx.Exec(ctx, statusSql.Sql, statusSql.Args...)
-	if err != nil {
-		return fmt.Errorf("TenderRepo.PutStatus - tx.Exec.statusSql: %v", err)
+	var prevStatus string
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&prevStatus); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("TenderRepo.PutStatus - r.Conn.QueryRow: %w", err)
 	}
-	_, err = tx.Exec(ctx, sql, args...)
+
+	statusSql, err := r.GetSqlData(tenderId, "status", status)
 	if err != nil {
-		return fmt.Errorf("TenderRepo.PutStatus - tx.Exec.version: %v", err)
+		return fmt.Errorf("TenderRepo.PutStatus - r.GetSqlData: %w", err)
 	}
 
-	err = tx.Commit(ctx)
+	_, err = r.Conn(ctx).Exec(ctx, statusSql.Sql, statusSql.Args...)
 	if err != nil {
-		return fmt.Errorf("TenderRepo.PutStatus - tx.Commit: %v", err)
+		return fmt.Errorf("TenderRepo.PutStatus - r.Conn.Exec.statusSql: %w", err)
+	}
+
+	if prevStatus != status {
+		if err = r.saveStatusEvent(ctx, tenderId, status); err != nil {
+			return fmt.Errorf("TenderRepo.PutStatus - r.saveStatusEvent: %w", err)
+		}
 	}
 	return nil
 }
 
 func (r *TenderRepo) EditTender(ctx context.Context, input entity.Tender, tenderId string) error {
-	var err error
+	// пустые поля не меняются, непустые обновляются одним запросом
+	fields := make(map[string]any)
+	if input.Name != "" {
+		fields["name"] = input.Name
+	}
+	if input.Description != "" {
+		fields["description"] = input.Description
+	}
+	if input.ServiceType != "" {
+		fields["type"] = input.ServiceType
+	}
 
-	type inputShort struct {
-		Name        string
-		Description string
-		ServiceType string
-	}
-	val := reflect.ValueOf(
-		inputShort{
-			Name:        input.Name,
-			Description: input.Description,
-			ServiceType: input.ServiceType,
-		},
-	)
-	typ := val.Type()
+	if len(fields) == 0 {
+		return nil
+	}
 
-	for i := 0; i < val.NumField(); i++ {
+	sql, args, err := r.Builder.Update(tender).SetMap(fields).Where("id = ?", tenderId).ToSql()
+	if err != nil {
+		return fmt.Errorf("TenderRepo.EditTender - r.Builder: %w", err)
+	}
 
-		var (
-			tx   pgx.Tx
-			sql  string
-			args []interface{}
-		)
+	if _, err = r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("TenderRepo.EditTender - r.Conn.Exec: %w", err)
+	}
+	return nil
+}
 
-		field := val.Field(i)
-		fieldType := typ.Field(i)
+// IncrementVersion увеличивает версию и сохраняет снимок в историю. При expectedVersion > 0
+// текущая версия должна с ней совпасть, иначе *repoerrs.VersionConflictError
+func (r *TenderRepo) IncrementVersion(ctx context.Context, tenderId string, expectedVersion int) error {
+	update := r.
+		Builder.
+		Update(tender).
+		Set("version", squirrel.Expr("version + 1")).
+		Where("id = ?", tenderId)
+	if expectedVersion > 0 {
+		update = update.Where("version = ?", expectedVersion)
+	}
 
-		if len(field.String()) == 0 || len(fieldType.Name) == 0 {
-			continue
-		}
+	sql, args, err := update.ToSql()
+	if err != nil {
+		return fmt.Errorf("TenderRepo.IncrementVersion - r.Builder: %w", err)
+	}
 
-		tx, err = r.Cluster.Begin(ctx)
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("TenderRepo.IncrementVersion - r.Conn.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		current, err := r.GetById(ctx, tenderId)
 		if err != nil {
-			return fmt.Errorf("TenderRepo.EditBid - r.Cluster.Begin: %v", err)
+			return err
 		}
+		return &repoerrs.VersionConflictError{CurrentVersion: current.Version}
+	}
 
-		var column string
-		switch fieldType.Name {
-		case "Name":
-			column = "name"
-		case "Description":
-			column = "description"
-		case "ServiceType":
-			column = "type"
-		default:
-			break
-		}
+	if err = r.saveHistory(ctx, tenderId); err != nil {
+		return fmt.Errorf("TenderRepo.IncrementVersion - r.saveHistory: %w", err)
+	}
+	return nil
+}
 
-		fieldVal := fmt.Sprintf("%s", field.String())
+// setStatus меняет статус тендера, инкрементирует версию и сохраняет снимок
+func (r *TenderRepo) setStatus(ctx context.Context, tenderId, status string) error {
+	sql, args, err := r.
+		Builder.
+		Update(tender).
+		Set("status", status).
+		Set("version", squirrel.Expr("version + 1")).
+		Where("id = ?", tenderId).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("TenderRepo.setStatus - r.Builder: %w", err)
+	}
 
-		if len(fieldVal) == 0 {
-			continue
-		}
-		sql, args, err = r.
-			Builder.
-			Update(tender).
-			Set(column, fieldVal).
-			Where("id = ?", tenderId).
-			ToSql()
+	if _, err = r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("TenderRepo.setStatus - r.Conn.Exec: %w", err)
+	}
 
-		if err != nil {
-			_ = tx.Rollback(ctx)
-			return fmt.Errorf("TenderRepo.EditBid - r.Builder: %v", err)
-		}
+	if err = r.saveStatusEvent(ctx, tenderId, status); err != nil {
+		return fmt.Errorf("TenderRepo.setStatus - r.saveStatusEvent: %w", err)
+	}
 
-		_, err = tx.Exec(ctx, sql, args...)
-		if err != nil {
-			_ = tx.Rollback(ctx)
-			return fmt.Errorf("TenderRepo.EditBid - tx.Exec: %v - %s - %s - %s", err, sql, column, fieldType.Name)
-		}
+	return r.saveHistory(ctx, tenderId)
+}
 
-		err = tx.Commit(ctx)
-		if err != nil {
-			_ = tx.Rollback(ctx)
-			return fmt.Errorf("TenderRepo.EditBid - tx.Commit: %v", err)
-		}
+// saveStatusEvent пишет в outbox событие публикации или закрытия тендера, остальные статусы событий не порождают
+func (r *TenderRepo) saveStatusEvent(ctx context.Context, tenderId, status string) error {
+	var eventType entity.EventType
+	switch status {
+	case tenderStatusPublished:
+		eventType = entity.EventTenderPublished
+	case tenderStatusClosed:
+		eventType = entity.EventTenderClosed
+	default:
+		return nil
+	}
+
+	sql, args, _ := r.Builder.
+		Select("organization_id").
+		From(tender).
+		Where("id = ?", tenderId).
+		ToSql()
+
+	payload := entity.TenderStatusPayload{TenderId: tenderId, Status: status}
+	if err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&payload.OrganizationId); err != nil {
+		return fmt.Errorf("TenderRepo.saveStatusEvent - r.Conn.QueryRow: %w", err)
+	}
+
+	return saveEvent(ctx, r.Conn(ctx), r.Builder, eventType, tenderId, payload)
+}
+
+// saveHistory сохраняет снимок текущего состояния тендера в tender_history
+func (r *TenderRepo) saveHistory(ctx context.Context, tenderId string) error {
+	sql, args, err := r.Builder.
+		Insert(tenderHistory).
+		Columns("tender_id", "name", "description", "type", "status", "organization_id", "version").
+		Select(
+			r.Builder.
+				Select("id", "name", "description", "type", "status", "organization_id", "version").
+				From(tender).
+				Where("id = ?", tenderId),
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("TenderRepo.saveHistory - r.Builder: %w", err)
+	}
+
+	if _, err = r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("TenderRepo.saveHistory - r.Conn.Exec: %w", err)
 	}
 	return nil
 }
 
-func (r *TenderRepo) IncrementVersion(ctx context.Context, tenderId string) error {
-	var (
-		err error
-		t   entity.Tender
-		tx  pgx.Tx
+func (r *TenderRepo) GetVersion(ctx context.Context, tenderId string, version int) (entity.Tender, error) {
+	sql, args, _ := r.Builder.
+		Select(
+			"h.tender_id", "h.name", "h.description", "h.type", "h.status",
+			"h.organization_id", "h.version", "t.created_at", "t.creator_username",
+		).
+		From(tenderHistory+" h").
+		Join(tender+" t ON t.id = h.tender_id").
+		Where("h.tender_id = ? AND h.version = ?", tenderId, version).
+		ToSql()
+
+	var output entity.Tender
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&output.Id,
+		&output.Name,
+		&output.Description,
+		&output.ServiceType,
+		&output.Status,
+		&output.OrganizationId,
+		&output.Version,
+		&output.CreatedAt,
+		&output.CreatorUsername,
 	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Tender{}, repoerrs.ErrNotFound
+		}
+		return entity.Tender{}, fmt.Errorf("TenderRepo - GetVersion - r.Conn.QueryRow: %w", err)
+	}
+	return output, nil
+}
+
+func (r *TenderRepo) GetVersions(ctx context.Context, tenderId string) ([]entity.Tender, error) {
+	sql, args, err := r.Builder.
+		Select(
+			"h.tender_id", "h.name", "h.description", "h.type", "h.status",
+			"h.organization_id", "h.version", "t.created_at", "t.creator_username",
+		).
+		From(tenderHistory+" h").
+		Join(tender+" t ON t.id = h.tender_id").
+		Where("h.tender_id = ?", tenderId).
+		OrderBy("h.version").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("TenderRepo - GetVersions - r.Builder: %w", err)
+	}
 
-	t, err = r.GetById(ctx, tenderId)
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
-		return fmt.Errorf("TenderRepo.IncrementVersion - r.GetById: %v", err)
+		return nil, fmt.Errorf("TenderRepo - GetVersions - r.Conn.Query: %w", err)
 	}
+	defer rows.Close()
 
-	tx, err = r.Cluster.Begin(ctx)
+	var output []entity.Tender
+	for rows.Next() {
+		var t entity.Tender
+		if err = rows.Scan(
+			&t.Id,
+			&t.Name,
+			&t.Description,
+			&t.ServiceType,
+			&t.Status,
+			&t.OrganizationId,
+			&t.Version,
+			&t.CreatedAt,
+			&t.CreatorUsername,
+		); err != nil {
+			return nil, fmt.Errorf("TenderRepo - GetVersions - rows.Scan: %w", err)
+		}
+		output = append(output, t)
+	}
+
+	return output, nil
+}
+
+// Rollback восстанавливает параметры тендера из снимка version как новую версию
+func (r *TenderRepo) Rollback(ctx context.Context, tenderId string, version int) error {
+	snapshot, err := r.GetVersion(ctx, tenderId, version)
 	if err != nil {
-		return fmt.Errorf("TenderRepo.IncrementVersion - r.Cluster.Begin: %v", err)
+		if errors.Is(err, repoerrs.ErrNotFound) {
+			return repoerrs.ErrNotFound
+		}
+		return fmt.Errorf("TenderRepo.Rollback - r.GetVersion: %w", err)
 	}
 
 	sql, args, err := r.
 		Builder.
 		Update(tender).
-		Set("version", t.Version+1).
-		Where("id = ?", t.Id).
+		Set("name", snapshot.Name).
+		Set("description", snapshot.Description).
+		Set("type", snapshot.ServiceType).
+		Set("version", squirrel.Expr("version + 1")).
+		Where("id = ?", tenderId).
 		ToSql()
 	if err != nil {
-		_ = tx.Rollback(ctx)
-		return fmt.Errorf("TenderRepo.IncrementVersion - tx.Exec: %v", err)
+		return fmt.Errorf("TenderRepo.Rollback - r.Builder: %w", err)
 	}
 
-	_, err = tx.Exec(ctx, sql, args...)
-	if err != nil {
-		_ = tx.Rollback(ctx)
-		return fmt.Errorf("TenderRepo.IncrementVersion - tx.Exec: %v", err)
+	if _, err = r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("TenderRepo.Rollback - r.Conn.Exec: %w", err)
 	}
 
-	err = tx.Commit(ctx)
-	if err != nil {
-		_ = tx.Rollback(ctx)
-		return fmt.Errorf("TenderRepo.IncrementVersion - tx.Commit: %v", err)
+	if err = r.saveHistory(ctx, tenderId); err != nil {
+		return fmt.Errorf("TenderRepo.Rollback - r.saveHistory: %w", err)
 	}
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -17,6 +18,11 @@ const (
 	employee = "employee"
 )
 
+// employeeColumns колонки пользователя в порядке полей entity.User
+var employeeColumns = []string{
+	"id", "username", "first_name", "last_name", "created_at", "updated_at", "deactivated_at",
+}
+
 type UserRepo struct {
 	*postgres.Database
 }
@@ -33,7 +39,7 @@ func (r *UserRepo) Create(ctx context.Context, user entity.User) (string, error)
 	).Suffix("RETURNING id").ToSql()
 
 	var id string
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(&id)
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&id)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if ok := errors.As(err, &pgErr); ok {
@@ -41,32 +47,33 @@ func (r *UserRepo) Create(ctx context.Context, user entity.User) (string, error)
 				return "", repoerrs.ErrAlreadyExists
 			}
 		}
-		return "", fmt.Errorf("UserRepo - Create - r.Cluster.QueryRow: %v", err)
+		return "", fmt.Errorf("UserRepo - Create - r.Conn.QueryRow: %w", err)
 	}
 	return id, nil
 }
 
 func (r *UserRepo) GetById(ctx context.Context, id string) (entity.User, error) {
 	sql, args, _ := r.Builder.
-		Select("*").
+		Select(employeeColumns...).
 		From(employee).
 		Where("id = ?", id).
 		ToSql()
 
 	var user entity.User
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&user.Id,
 		&user.Username,
 		&user.FirstName,
 		&user.LastName,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DeactivatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.User{}, repoerrs.ErrNotFound
 		}
-		return entity.User{}, fmt.Errorf("UserRepo - GetById - r.Cluster.QueryRow: %v", err)
+		return entity.User{}, fmt.Errorf("UserRepo - GetById - r.Conn.QueryRow: %w", err)
 	}
 
 	return user, nil
@@ -74,26 +81,146 @@ func (r *UserRepo) GetById(ctx context.Context, id string) (entity.User, error)
 
 func (r *UserRepo) GetByUsername(ctx context.Context, username string) (entity.User, error) {
 	sql, args, _ := r.Builder.
-		Select("*").
+		Select(employeeColumns...).
 		From(employee).
 		Where("username = ?", username).
 		ToSql()
 
 	var user entity.User
-	err := r.Cluster.QueryRow(ctx, sql, args...).Scan(
+	err := r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
 		&user.Id,
 		&user.Username,
 		&user.FirstName,
 		&user.LastName,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DeactivatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.User{}, repoerrs.ErrNotFound
 		}
-		return entity.User{}, fmt.Errorf("UserRepo - GetByUsername - r.Cluster.QueryRow: %v", err)
+		return entity.User{}, fmt.Errorf("UserRepo - GetByUsername - r.Conn.QueryRow: %w", err)
 	}
 
 	return user, nil
 }
+
+// GetPagination список пользователей, query - поиск по username, имени и фамилии
+func (r *UserRepo) GetPagination(ctx context.Context, limit, offset int, query string) ([]entity.User, error) {
+	if limit > maxPaginationLimit {
+		limit = maxPaginationLimit
+	}
+	if limit == 0 {
+		limit = defaultPaginationLimit
+	}
+
+	builder := r.Builder.
+		Select(employeeColumns...).
+		From(employee)
+	if query != "" {
+		pattern := "%" + escapeLike(query) + "%"
+		builder = builder.Where(
+			squirrel.Or{
+				squirrel.ILike{"username": pattern},
+				squirrel.ILike{"first_name": pattern},
+				squirrel.ILike{"last_name": pattern},
+			},
+		)
+	}
+
+	sql, args, err := builder.
+		OrderBy("username").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - GetPagination - r.Builder: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("UserRepo - GetPagination - r.Conn.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var output []entity.User
+	for rows.Next() {
+		var user entity.User
+		if err = rows.Scan(
+			&user.Id,
+			&user.Username,
+			&user.FirstName,
+			&user.LastName,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.DeactivatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("UserRepo - GetPagination - rows.Scan: %w", err)
+		}
+		output = append(output, user)
+	}
+
+	return output, nil
+}
+
+// Update меняет непустые поля профиля активного пользователя и обновляет updated_at
+func (r *UserRepo) Update(ctx context.Context, id string, input entity.User) (entity.User, error) {
+	builder := r.Builder.
+		Update(employee).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where("id = ? AND deactivated_at IS NULL", id)
+	if input.FirstName != "" {
+		builder = builder.Set("first_name", input.FirstName)
+	}
+	if input.LastName != "" {
+		builder = builder.Set("last_name", input.LastName)
+	}
+
+	sql, args, err := builder.
+		Suffix("RETURNING id, username, first_name, last_name, created_at, updated_at, deactivated_at").
+		ToSql()
+	if err != nil {
+		return entity.User{}, fmt.Errorf("UserRepo - Update - r.Builder: %w", err)
+	}
+
+	var user entity.User
+	err = r.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&user.Id,
+		&user.Username,
+		&user.FirstName,
+		&user.LastName,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.DeactivatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.User{}, repoerrs.ErrNotFound
+		}
+		return entity.User{}, fmt.Errorf("UserRepo - Update - r.Conn.QueryRow: %w", err)
+	}
+	return user, nil
+}
+
+// Deactivate помечает пользователя деактивированным, его тендеры и предложения сохраняются
+func (r *UserRepo) Deactivate(ctx context.Context, id string) error {
+	sql, args, err := r.Builder.
+		Update(employee).
+		Set("deactivated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where("id = ? AND deactivated_at IS NULL", id).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("UserRepo - Deactivate - r.Builder: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("UserRepo - Deactivate - r.Conn.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+	return nil
+}
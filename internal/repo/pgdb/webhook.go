@@ -0,0 +1,312 @@
+package pgdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"tender-service/internal/entity"
+	"tender-service/internal/repo/repoerrs"
+	"tender-service/pkg/postgres"
+)
+
+const (
+	webhookTable         = "webhook"
+	webhookDeliveryTable = "webhook_delivery"
+)
+
+var webhookColumns = []string{"id", "organization_id", "url", "secret", "event_types", "created_at"}
+
+var webhookDeliveryColumns = []string{
+	"id", "webhook_id", "event_id", "event_type", "payload", "status", "attempts",
+	"next_attempt_at", "response_code", "last_error", "created_at", "delivered_at",
+}
+
+type WebhookRepo struct {
+	*postgres.Database
+}
+
+func NewWebhookRepo(db *postgres.Database) *WebhookRepo {
+	return &WebhookRepo{db}
+}
+
+func (r *WebhookRepo) Create(ctx context.Context, input entity.Webhook) (entity.Webhook, error) {
+	sql, args, _ := r.Builder.
+		Insert(webhookTable).
+		Columns("organization_id", "url", "secret", "event_types").
+		Values(input.OrganizationId, input.Url, input.Secret, input.EventTypes).
+		Suffix("RETURNING id, organization_id, url, secret, event_types, created_at").
+		ToSql()
+
+	output, err := scanWebhook(r.Conn(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("WebhookRepo - Create - r.Conn.QueryRow: %w", err)
+	}
+	return output, nil
+}
+
+func (r *WebhookRepo) GetById(ctx context.Context, id string) (entity.Webhook, error) {
+	sql, args, _ := r.Builder.
+		Select(webhookColumns...).
+		From(webhookTable).
+		Where("id = ?", id).
+		ToSql()
+
+	output, err := scanWebhook(r.Conn(ctx).QueryRow(ctx, sql, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Webhook{}, repoerrs.ErrNotFound
+		}
+		return entity.Webhook{}, fmt.Errorf("WebhookRepo - GetById - r.Conn.QueryRow: %w", err)
+	}
+	return output, nil
+}
+
+func (r *WebhookRepo) GetByOrganization(ctx context.Context, organizationId string) ([]entity.Webhook, error) {
+	sql, args, _ := r.Builder.
+		Select(webhookColumns...).
+		From(webhookTable).
+		Where("organization_id = ?", organizationId).
+		OrderBy("created_at", "id").
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - GetByOrganization - r.Conn.Query: %w", err)
+	}
+	output, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Webhook, error) {
+		return scanWebhook(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - GetByOrganization - pgx.CollectRows: %w", err)
+	}
+	return output, nil
+}
+
+// Delete удаляет подписку организации вместе с журналом доставок
+func (r *WebhookRepo) Delete(ctx context.Context, organizationId, id string) error {
+	sql, args, _ := r.Builder.
+		Delete(webhookTable).
+		Where("id = ? AND organization_id = ?", id, organizationId).
+		ToSql()
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("WebhookRepo - Delete - r.Conn.Exec: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return repoerrs.ErrNotFound
+	}
+	return nil
+}
+
+// GetDeliveries журнал доставок подписки, новые первыми
+func (r *WebhookRepo) GetDeliveries(ctx context.Context, webhookId string, limit, offset int) (
+	[]entity.WebhookDelivery, error,
+) {
+	sql, args, _ := r.Builder.
+		Select(webhookDeliveryColumns...).
+		From(webhookDeliveryTable).
+		Where("webhook_id = ?", webhookId).
+		OrderBy("created_at DESC", "id").
+		Limit(uint64(normalizeLimit(limit))).
+		Offset(uint64(offset)).
+		ToSql()
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - GetDeliveries - r.Conn.Query: %w", err)
+	}
+	output, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.WebhookDelivery, error) {
+		var d entity.WebhookDelivery
+		err := row.Scan(
+			&d.Id,
+			&d.WebhookId,
+			&d.EventId,
+			&d.EventType,
+			&d.Payload,
+			&d.Status,
+			&d.Attempts,
+			&d.NextAttemptAt,
+			&d.ResponseCode,
+			&d.LastError,
+			&d.CreatedAt,
+			&d.DeliveredAt,
+		)
+		return d, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - GetDeliveries - pgx.CollectRows: %w", err)
+	}
+	return output, nil
+}
+
+// eventOrganizations организации, которых касается событие:
+// для тендера и нового предложения - организация тендера, для решения - автор предложения
+func eventOrganizations(event entity.Event) (squirrel.Sqlizer, bool) {
+	switch event.Type {
+	case entity.EventTenderPublished, entity.EventTenderClosed:
+		return squirrel.Select("organization_id").From(tender).Where("id = ?", event.AggregateId), true
+	case entity.EventBidCreated:
+		return squirrel.Select("t.organization_id").
+			From(bidTable+" b").
+			Join(tender+" t ON t.id = b.tender_id").
+			Where("b.id = ?", event.AggregateId), true
+	case entity.EventBidApproved, entity.EventBidRejected:
+		return squirrel.Select("author_organization_id").
+			From(bidTable).
+			Where("id = ? AND author_organization_id IS NOT NULL", event.AggregateId).
+			Suffix(
+				"UNION SELECT r.organization_id FROM "+bidTable+" b "+
+					"JOIN "+orgResponsible+" r ON r.user_id = b.author_user_id WHERE b.id = ?",
+				event.AggregateId,
+			), true
+	}
+	return nil, false
+}
+
+// Enqueue создает доставки события body по всем подходящим подпискам затронутых организаций.
+// Повторный вызов для того же события новых доставок не создает. Возвращает число созданных доставок.
+func (r *WebhookRepo) Enqueue(ctx context.Context, event entity.Event, body []byte) (int, error) {
+	orgs, ok := eventOrganizations(event)
+	if !ok {
+		return 0, nil
+	}
+
+	sql, args, err := r.Builder.
+		Insert(webhookDeliveryTable).
+		Columns("webhook_id", "event_id", "event_type", "payload").
+		Select(
+			squirrel.Select("w.id").
+				Column("?::uuid", event.Id).
+				Column("?::varchar", string(event.Type)).
+				Column("?::jsonb", body).
+				From(webhookTable+" w").
+				Join(organization+" o ON o.id = w.organization_id AND o.deleted_at IS NULL").
+				Where("?::varchar = ANY(w.event_types)", string(event.Type)).
+				Where(squirrel.Expr("w.organization_id IN (?)", orgs)),
+		).
+		Suffix("ON CONFLICT ON CONSTRAINT webhook_delivery_event_key DO NOTHING").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("WebhookRepo - Enqueue - r.Builder: %w", err)
+	}
+
+	tag, err := r.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("WebhookRepo - Enqueue - r.Conn.Exec: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ClaimDue захватывает до limit доставок, срок которых наступил, сдвигая следующую попытку на lease:
+// если отправитель упадет, доставка будет повторена после истечения lease.
+func (r *WebhookRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) (
+	[]entity.WebhookDelivery, error,
+) {
+	due := squirrel.Select("id").
+		From(webhookDeliveryTable).
+		Where("status = ? AND next_attempt_at <= CURRENT_TIMESTAMP", entity.WebhookDeliveryPending).
+		OrderBy("next_attempt_at").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	sql, args, err := r.Builder.
+		Update(webhookDeliveryTable+" d").
+		Set("next_attempt_at", squirrel.Expr("CURRENT_TIMESTAMP + ?::float8 * interval '1 second'", lease.Seconds())).
+		From(webhookTable + " w").
+		Where("w.id = d.webhook_id").
+		Where(squirrel.Expr("d.id IN (?)", due)).
+		Suffix("RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, w.url, w.secret").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - ClaimDue - r.Builder: %w", err)
+	}
+
+	rows, err := r.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - ClaimDue - r.Conn.Query: %w", err)
+	}
+	output, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.WebhookDelivery, error) {
+		d := entity.WebhookDelivery{Status: entity.WebhookDeliveryPending}
+		err := row.Scan(
+			&d.Id,
+			&d.WebhookId,
+			&d.EventId,
+			&d.EventType,
+			&d.Payload,
+			&d.Attempts,
+			&d.Url,
+			&d.Secret,
+		)
+		return d, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WebhookRepo - ClaimDue - pgx.CollectRows: %w", err)
+	}
+	return output, nil
+}
+
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, id string, responseCode int) error {
+	sql, args, _ := r.Builder.
+		Update(webhookDeliveryTable).
+		Set("status", entity.WebhookDeliveryDelivered).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("response_code", responseCode).
+		Set("last_error", nil).
+		Set("delivered_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Where("id = ?", id).
+		ToSql()
+
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("WebhookRepo - MarkDelivered - r.Conn.Exec: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed фиксирует неудачную попытку. retryAfter > 0 планирует повтор,
+// иначе доставка окончательно переходит в Failed. responseCode 0 - ответа не было.
+func (r *WebhookRepo) MarkFailed(
+	ctx context.Context, id string, responseCode int, lastError string, retryAfter time.Duration,
+) error {
+	var code *int
+	if responseCode != 0 {
+		code = &responseCode
+	}
+
+	update := r.Builder.
+		Update(webhookDeliveryTable).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("response_code", code).
+		Set("last_error", lastError).
+		Where("id = ?", id)
+	if retryAfter > 0 {
+		update = update.Set(
+			"next_attempt_at", squirrel.Expr("CURRENT_TIMESTAMP + ?::float8 * interval '1 second'", retryAfter.Seconds()),
+		)
+	} else {
+		update = update.Set("status", entity.WebhookDeliveryFailed)
+	}
+
+	sql, args, _ := update.ToSql()
+	if _, err := r.Conn(ctx).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("WebhookRepo - MarkFailed - r.Conn.Exec: %w", err)
+	}
+	return nil
+}
+
+func scanWebhook(row pgx.Row) (entity.Webhook, error) {
+	var output entity.Webhook
+	err := row.Scan(
+		&output.Id,
+		&output.OrganizationId,
+		&output.Url,
+		&output.Secret,
+		&output.EventTypes,
+		&output.CreatedAt,
+	)
+	return output, err
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"tender-service/internal/entity"
 	"tender-service/internal/repo/pgdb"
@@ -12,11 +13,17 @@ type User interface {
 	Create(ctx context.Context, input entity.User) (string, error)
 	GetById(ctx context.Context, id string) (entity.User, error)
 	GetByUsername(ctx context.Context, username string) (entity.User, error)
+	GetPagination(ctx context.Context, limit, offset int, query string) ([]entity.User, error)
+	Update(ctx context.Context, id string, input entity.User) (entity.User, error)
+	Deactivate(ctx context.Context, id string) error
 }
 
 type Organization interface {
 	Create(ctx context.Context, input entity.Organization) (entity.Organization, error)
 	GetById(ctx context.Context, id string) (entity.Organization, error)
+	GetPagination(ctx context.Context, limit, offset int, name string) ([]entity.Organization, error)
+	Update(ctx context.Context, id string, input entity.Organization) (entity.Organization, error)
+	Delete(ctx context.Context, id string) error
 }
 
 type OrgResponsible interface {
@@ -27,46 +34,114 @@ type OrgResponsible interface {
 	GetByIds(ctx context.Context, input entity.OrgResponsible) (
 		entity.OrgResponsible, error,
 	)
+	GetByOrganizationPagination(ctx context.Context, limit, offset int, organizationId string) (
+		[]entity.OrgResponsible, error,
+	)
+	Delete(ctx context.Context, input entity.OrgResponsible) error
 }
 
 type Tender interface {
 	Create(ctx context.Context, input entity.Tender) (entity.Tender, error)
 	GetById(ctx context.Context, id string) (entity.Tender, error)
-	GetByTypePagination(ctx context.Context, limit, offset int, serviceType []string) (
-		[]entity.Tender, error,
+	GetByTypePagination(ctx context.Context, page entity.Pagination, filter entity.TenderFilter, userId string) (
+		entity.Page[entity.Tender], error,
 	)
-	GetMyPagination(ctx context.Context, limit, offset int, username string) (
-		[]entity.Tender, error,
+	GetMyPagination(ctx context.Context, page entity.Pagination, username string) (
+		entity.Page[entity.Tender], error,
 	)
+	Search(ctx context.Context, page entity.Pagination, language, q, userId string) ([]entity.Tender, error)
 	PutStatus(ctx context.Context, tenderId, status string) error
 	EditTender(ctx context.Context, input entity.Tender, tenderId string) error
-	IncrementVersion(ctx context.Context, tenderId string) error
+	IncrementVersion(ctx context.Context, tenderId string, expectedVersion int) error
+	GetVersion(ctx context.Context, tenderId string, version int) (entity.Tender, error)
+	GetVersions(ctx context.Context, tenderId string) ([]entity.Tender, error)
+	Rollback(ctx context.Context, tenderId string, version int) error
 }
 
 type Bid interface {
 	Create(ctx context.Context, input entity.Bid) (entity.Bid, error)
 	GetById(ctx context.Context, bidId string) (entity.Bid, error)
-	GetMyPagination(ctx context.Context, limit, offset int, authorId string) ([]entity.Bid, error)
-	GetByTenderID(ctx context.Context, limit, offset int, authorId, tenderId string) ([]entity.Bid, error)
+	ExistsByAuthor(ctx context.Context, tenderId, authorId string) (bool, error)
+	GetMyPagination(ctx context.Context, page entity.Pagination, authorId string) (entity.Page[entity.Bid], error)
+	GetByTenderID(ctx context.Context, page entity.Pagination, userId, tenderId string) (
+		entity.Page[entity.Bid], error,
+	)
+	Search(ctx context.Context, page entity.Pagination, language, q, userId string) ([]entity.Bid, error)
 	PutStatus(ctx context.Context, bidId, status string) error
 	EditBid(ctx context.Context, input entity.Bid, bidId string) error
-	IncrementVersion(ctx context.Context, bidId string) error
+	IncrementVersion(ctx context.Context, bidId string, expectedVersion int) error
+	GetVersion(ctx context.Context, bidId string, version int) (entity.Bid, error)
+	GetVersions(ctx context.Context, bidId string) ([]entity.Bid, error)
+	Rollback(ctx context.Context, bidId string, version int) error
+}
+
+type BidDecision interface {
+	Submit(ctx context.Context, input entity.BidDecision, quorumLimit int) error
+}
+
+type BidReview interface {
+	Create(ctx context.Context, input entity.BidReview) (entity.BidReview, error)
+	GetByAuthorPagination(ctx context.Context, limit, offset int, authorId, organizationId string) (
+		[]entity.BidReview, error,
+	)
+}
+
+type Outbox interface {
+	Dispatch(ctx context.Context, limit, maxAttempts int, publish func(context.Context, entity.Event) error) (
+		int, error,
+	)
+}
+
+type Webhook interface {
+	Create(ctx context.Context, input entity.Webhook) (entity.Webhook, error)
+	GetById(ctx context.Context, id string) (entity.Webhook, error)
+	GetByOrganization(ctx context.Context, organizationId string) ([]entity.Webhook, error)
+	Delete(ctx context.Context, organizationId, id string) error
+	GetDeliveries(ctx context.Context, webhookId string, limit, offset int) ([]entity.WebhookDelivery, error)
+	Enqueue(ctx context.Context, event entity.Event, body []byte) (int, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id string, responseCode int) error
+	MarkFailed(ctx context.Context, id string, responseCode int, lastError string, retryAfter time.Duration) error
+}
+
+type Audit interface {
+	Create(ctx context.Context, input entity.AuditEntry) error
+	GetByEntity(ctx context.Context, entityId, userId string, limit, offset int) ([]entity.AuditEntry, error)
+}
+
+// TxManager выполняет fn одной транзакцией: репозитории, вызванные с ctx из fn, присоединяются к ней.
+// Методы, меняющие несколько таблиц (Create, PutStatus, IncrementVersion, Rollback, Submit), вызываются внутри Do
+type TxManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Repositories struct {
+	TxManager TxManager
+
 	User
 	Organization
 	OrgResponsible
 	Tender
 	Bid
+	BidDecision
+	BidReview
+	Outbox
+	Webhook
+	Audit
 }
 
 func NewRepositories(db *postgres.Database) *Repositories {
 	return &Repositories{
+		TxManager:      postgres.NewTxManager(db),
 		User:           pgdb.NewUserRepo(db),
 		Organization:   pgdb.NewOrganizationRepo(db),
 		OrgResponsible: pgdb.NewOrgResponsibleRepo(db),
 		Tender:         pgdb.NewTenderRepo(db),
 		Bid:            pgdb.NewBidRepo(db),
+		BidDecision:    pgdb.NewBidDecisionRepo(db),
+		BidReview:      pgdb.NewBidReviewRepo(db),
+		Outbox:         pgdb.NewOutboxRepo(db),
+		Webhook:        pgdb.NewWebhookRepo(db),
+		Audit:          pgdb.NewAuditRepo(db),
 	}
 }
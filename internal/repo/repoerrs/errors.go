@@ -1,8 +1,28 @@
 package repoerrs
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrAlreadyExists = errors.New("already exists")
+	ErrInvalidStatus = errors.New("invalid status")
+	ErrConflict      = errors.New("conflict")
+	// ErrVersionConflict версия сущности изменилась с момента чтения
+	ErrVersionConflict = errors.New("version conflict")
 )
+
+// VersionConflictError ErrVersionConflict с версией сущности на момент конфликта
+type VersionConflictError struct {
+	CurrentVersion int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%v: current version %d", ErrVersionConflict, e.CurrentVersion)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
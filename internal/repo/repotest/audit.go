@@ -0,0 +1,50 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+)
+
+func testAudit(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	org := newOrganization(t, repos, "org_"+unique())
+	responsible := newResponsible(t, repos, org)
+	tender := newTender(t, repos, org, responsible, "tender_"+unique())
+
+	for i := 0; i <= MaxPaginationLimit; i++ {
+		version := i + 1
+		requireNoError(t, repos.Audit.Create(ctx, entity.AuditEntry{
+			ActorId:       responsible.Id,
+			ActorUsername: responsible.Username,
+			Action:        entity.AuditActionEdit,
+			EntityType:    entity.AuditEntityTender,
+			EntityId:      tender.Id,
+			NewVersion:    &version,
+		}))
+	}
+
+	entries, err := repos.Audit.GetByEntity(ctx, tender.Id, responsible.Id, 0, 0)
+	requireNoError(t, err)
+	requireLen(t, entries, DefaultPaginationLimit)
+	if entries[0].OrganizationId == nil || *entries[0].OrganizationId != org.Id {
+		t.Fatalf("entry organization is %v, want %s", entries[0].OrganizationId, org.Id)
+	}
+
+	entries, err = repos.Audit.GetByEntity(ctx, tender.Id, responsible.Id, MaxPaginationLimit+10, 0)
+	requireNoError(t, err)
+	requireLen(t, entries, MaxPaginationLimit)
+
+	entries, err = repos.Audit.GetByEntity(ctx, tender.Id, responsible.Id, MaxPaginationLimit, MaxPaginationLimit)
+	requireNoError(t, err)
+	requireLen(t, entries, 1)
+
+	// журнал виден только ответственным за организацию сущности
+	outsider := newUser(t, repos, "user_"+unique())
+	entries, err = repos.Audit.GetByEntity(ctx, tender.Id, outsider.Id, 0, 0)
+	requireNoError(t, err)
+	requireLen(t, entries, 0)
+}
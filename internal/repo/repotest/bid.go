@@ -0,0 +1,123 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/repoerrs"
+)
+
+func testBid(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	org := newOrganization(t, repos, "org_"+unique())
+	tender := newTender(t, repos, org, newResponsible(t, repos, org), "tender_"+unique())
+	author := newUser(t, repos, "author_"+unique())
+
+	t.Run("CreateGet", func(t *testing.T) {
+		bid := newBid(t, repos, tender, author, "bid_"+unique())
+		if bid.Version != 1 || bid.Status != "Created" {
+			t.Fatalf("new bid has version %d and status %s, want 1 and Created", bid.Version, bid.Status)
+		}
+
+		got, err := repos.Bid.GetById(ctx, bid.Id)
+		requireNoError(t, err)
+		if got.Name != bid.Name || got.TenderId != tender.Id || got.AuthorId != author.Id {
+			t.Fatalf("GetById returned %+v, want %+v", got, bid)
+		}
+
+		versions, err := repos.Bid.GetVersions(ctx, bid.Id)
+		requireNoError(t, err)
+		requireLen(t, versions, 1)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		bid := newBid(t, repos, tender, author, "bid_"+unique())
+
+		_, err := repos.Bid.GetById(ctx, missingId())
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		requireError(t, repos.Bid.IncrementVersion(ctx, missingId(), 0), repoerrs.ErrNotFound)
+
+		_, err = repos.Bid.GetVersion(ctx, bid.Id, bid.Version+1)
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		requireError(t, repos.Bid.Rollback(ctx, bid.Id, bid.Version+1), repoerrs.ErrNotFound)
+	})
+
+	t.Run("ExistsByAuthor", func(t *testing.T) {
+		other := newUser(t, repos, "author_"+unique())
+		newBid(t, repos, tender, other, "bid_"+unique())
+
+		exists, err := repos.Bid.ExistsByAuthor(ctx, tender.Id, other.Id)
+		requireNoError(t, err)
+		if !exists {
+			t.Fatalf("ExistsByAuthor returned false for author with a bid")
+		}
+
+		exists, err = repos.Bid.ExistsByAuthor(ctx, missingId(), other.Id)
+		requireNoError(t, err)
+		if exists {
+			t.Fatalf("ExistsByAuthor returned true for another tender")
+		}
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		bid := newBid(t, repos, tender, author, "bid_"+unique())
+
+		requireNoError(t, repos.Bid.EditBid(ctx, entity.Bid{Name: "edited_" + unique()}, bid.Id))
+		requireNoError(t, repos.Bid.IncrementVersion(ctx, bid.Id, bid.Version))
+
+		err := repos.Bid.IncrementVersion(ctx, bid.Id, bid.Version)
+		requireError(t, err, repoerrs.ErrVersionConflict)
+		var conflict *repoerrs.VersionConflictError
+		if !errors.As(err, &conflict) || conflict.CurrentVersion != bid.Version+1 {
+			t.Fatalf("got error %v, want version conflict with current version %d", err, bid.Version+1)
+		}
+
+		requireNoError(t, repos.Bid.Rollback(ctx, bid.Id, 1))
+
+		got, err := repos.Bid.GetById(ctx, bid.Id)
+		requireNoError(t, err)
+		if got.Version != 3 || got.Name != bid.Name {
+			t.Fatalf("after rollback got version %d and name %s, want 3 and %s", got.Version, got.Name, bid.Name)
+		}
+
+		versions, err := repos.Bid.GetVersions(ctx, bid.Id)
+		requireNoError(t, err)
+		requireLen(t, versions, 3)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		author := newUser(t, repos, "author_"+unique())
+		for i := 0; i <= MaxPaginationLimit; i++ {
+			newBid(t, repos, tender, author, fmt.Sprintf("bid_%02d", i))
+		}
+
+		page, err := repos.Bid.GetMyPagination(ctx, entity.Pagination{}, author.Id)
+		requireNoError(t, err)
+		requireLen(t, page.Items, DefaultPaginationLimit)
+
+		page, err = repos.Bid.GetMyPagination(ctx, entity.Pagination{Limit: MaxPaginationLimit + 10}, author.Id)
+		requireNoError(t, err)
+		requireLen(t, page.Items, MaxPaginationLimit)
+		if page.Items[0].Name != "bid_00" {
+			t.Fatalf("first bid is %s, want bid_00", page.Items[0].Name)
+		}
+
+		last, err := repos.Bid.GetMyPagination(ctx, entity.Pagination{After: page.Next}, author.Id)
+		requireNoError(t, err)
+		requireLen(t, last.Items, 1)
+		if last.Next != nil {
+			t.Fatalf("last page has next cursor")
+		}
+
+		page, err = repos.Bid.GetByTenderID(ctx, entity.Pagination{Limit: MaxPaginationLimit + 10}, author.Id, tender.Id)
+		requireNoError(t, err)
+		requireLen(t, page.Items, MaxPaginationLimit)
+	})
+}
@@ -0,0 +1,59 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/repoerrs"
+)
+
+func testBidDecision(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	org := newOrganization(t, repos, "org_"+unique())
+	first, second := newResponsible(t, repos, org), newResponsible(t, repos, org)
+	tender := newTender(t, repos, org, first, "tender_"+unique())
+	author := newUser(t, repos, "author_"+unique())
+	bid := newBid(t, repos, tender, author, "bid_"+unique())
+
+	// Submit не открывает собственной транзакции и вызывается внутри TxManager.Do, как в сервисе
+	submit := func(bidId string, user entity.User) error {
+		return repos.TxManager.Do(ctx, func(ctx context.Context) error {
+			return repos.BidDecision.Submit(
+				ctx, entity.BidDecision{BidId: bidId, UserId: user.Id, Decision: "Approved"}, 3,
+			)
+		})
+	}
+	approve := func(user entity.User) error {
+		return submit(bid.Id, user)
+	}
+
+	requireError(t, submit(missingId(), first), repoerrs.ErrNotFound)
+
+	// решения принимаются только по опубликованным предложениям опубликованных тендеров
+	requireError(t, approve(first), repoerrs.ErrInvalidStatus)
+
+	requireNoError(t, repos.Tender.PutStatus(ctx, tender.Id, "Published"))
+	requireNoError(t, repos.Bid.PutStatus(ctx, bid.Id, "Published"))
+
+	requireNoError(t, approve(first))
+	requireError(t, approve(first), repoerrs.ErrAlreadyExists)
+
+	// кворум min(3, 2 ответственных) набран: предложение одобрено, тендер закрыт, версии увеличены
+	requireNoError(t, approve(second))
+
+	gotBid, err := repos.Bid.GetById(ctx, bid.Id)
+	requireNoError(t, err)
+	if gotBid.Status != "Approved" || gotBid.Version != bid.Version+1 {
+		t.Fatalf("bid has status %s and version %d, want Approved and %d", gotBid.Status, gotBid.Version, bid.Version+1)
+	}
+
+	gotTender, err := repos.Tender.GetById(ctx, tender.Id)
+	requireNoError(t, err)
+	if gotTender.Status != "Closed" || gotTender.Version != tender.Version+1 {
+		t.Fatalf("tender has status %s and version %d, want Closed and %d",
+			gotTender.Status, gotTender.Version, tender.Version+1)
+	}
+}
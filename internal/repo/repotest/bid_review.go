@@ -0,0 +1,48 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+)
+
+func testBidReview(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	org := newOrganization(t, repos, "org_"+unique())
+	reviewer := newResponsible(t, repos, org)
+	tender := newTender(t, repos, org, reviewer, "tender_"+unique())
+	author := newUser(t, repos, "author_"+unique())
+	bid := newBid(t, repos, tender, author, "bid_"+unique())
+
+	for i := 0; i <= MaxPaginationLimit; i++ {
+		review, err := repos.BidReview.Create(ctx, entity.BidReview{
+			Description:    "review",
+			BidId:          bid.Id,
+			UserId:         reviewer.Id,
+			OrganizationId: org.Id,
+		})
+		requireNoError(t, err)
+		if review.Id == "" || review.BidId != bid.Id {
+			t.Fatalf("Create returned %+v", review)
+		}
+	}
+
+	reviews, err := repos.BidReview.GetByAuthorPagination(ctx, 0, 0, author.Id, "")
+	requireNoError(t, err)
+	requireLen(t, reviews, DefaultPaginationLimit)
+
+	reviews, err = repos.BidReview.GetByAuthorPagination(ctx, MaxPaginationLimit+10, 0, author.Id, org.Id)
+	requireNoError(t, err)
+	requireLen(t, reviews, MaxPaginationLimit)
+
+	reviews, err = repos.BidReview.GetByAuthorPagination(ctx, MaxPaginationLimit, MaxPaginationLimit, author.Id, "")
+	requireNoError(t, err)
+	requireLen(t, reviews, 1)
+
+	reviews, err = repos.BidReview.GetByAuthorPagination(ctx, 0, 0, author.Id, missingId())
+	requireNoError(t, err)
+	requireLen(t, reviews, 0)
+}
@@ -0,0 +1,154 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/repoerrs"
+)
+
+func testOrgResponsible(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	t.Run("CreateGet", func(t *testing.T) {
+		org := newOrganization(t, repos, "org_"+unique())
+		user := newUser(t, repos, "user_"+unique())
+
+		created, err := repos.OrgResponsible.Create(ctx, entity.OrgResponsible{OrganizationId: org.Id, UserId: user.Id})
+		requireNoError(t, err)
+
+		got, err := repos.OrgResponsible.GetById(ctx, created.Id)
+		requireNoError(t, err)
+		if got != created {
+			t.Fatalf("GetById returned %+v, want %+v", got, created)
+		}
+
+		got, err = repos.OrgResponsible.GetByIds(ctx, entity.OrgResponsible{OrganizationId: org.Id, UserId: user.Id})
+		requireNoError(t, err)
+		if got != created {
+			t.Fatalf("GetByIds returned %+v, want %+v", got, created)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		org := newOrganization(t, repos, "org_"+unique())
+		user := newUser(t, repos, "user_"+unique())
+		input := entity.OrgResponsible{OrganizationId: org.Id, UserId: user.Id}
+
+		_, err := repos.OrgResponsible.GetById(ctx, missingId())
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		_, err = repos.OrgResponsible.GetByIds(ctx, input)
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		requireError(t, repos.OrgResponsible.Delete(ctx, input), repoerrs.ErrNotFound)
+	})
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		org := newOrganization(t, repos, "org_"+unique())
+		user := newResponsible(t, repos, org)
+
+		_, err := repos.OrgResponsible.Create(ctx, entity.OrgResponsible{OrganizationId: org.Id, UserId: user.Id})
+		requireError(t, err, repoerrs.ErrAlreadyExists)
+
+		// пользователь может отвечать только за одну организацию
+		other := newOrganization(t, repos, "org_"+unique())
+		_, err = repos.OrgResponsible.Create(ctx, entity.OrgResponsible{OrganizationId: other.Id, UserId: user.Id})
+		requireError(t, err, repoerrs.ErrConflict)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		org := newOrganization(t, repos, "org_"+unique())
+		user := newResponsible(t, repos, org)
+		input := entity.OrgResponsible{OrganizationId: org.Id, UserId: user.Id}
+
+		requireNoError(t, repos.OrgResponsible.Delete(ctx, input))
+		requireError(t, repos.OrgResponsible.Delete(ctx, input), repoerrs.ErrNotFound)
+	})
+
+	t.Run("DeletedOrganization", func(t *testing.T) {
+		org := newOrganization(t, repos, "org_"+unique())
+		user := newResponsible(t, repos, org)
+
+		// ответственные удаленной организации теряют права на нее
+		requireNoError(t, repos.Organization.Delete(ctx, org.Id))
+		_, err := repos.OrgResponsible.GetByIds(ctx, entity.OrgResponsible{OrganizationId: org.Id, UserId: user.Id})
+		requireError(t, err, repoerrs.ErrNotFound)
+	})
+
+	t.Run("DeletedOrganizationVisibility", func(t *testing.T) {
+		org := newOrganization(t, repos, "org_"+unique())
+		user := newResponsible(t, repos, org)
+		tender := newTender(t, repos, org, user, "tender_"+unique())
+
+		bid := newBid(t, repos, tender, newUser(t, repos, "user_"+unique()), "bid_"+unique())
+		requireNoError(t, repos.Bid.PutStatus(ctx, bid.Id, "Published"))
+		_, err := repos.Bid.Create(ctx, entity.Bid{
+			Name:        "bid_" + unique(),
+			Description: "description",
+			TenderId:    tender.Id,
+			AuthorType:  entity.BidAuthorTypeOrganization,
+			AuthorId:    org.Id,
+		})
+		requireNoError(t, err)
+
+		version := tender.Version
+		requireNoError(t, repos.Audit.Create(ctx, entity.AuditEntry{
+			ActorId:    user.Id,
+			Action:     entity.AuditActionCreate,
+			EntityType: entity.AuditEntityTender,
+			EntityId:   tender.Id,
+			NewVersion: &version,
+		}))
+
+		// черновик тендера, предложения организации, предложения на тендер и журнал видны ответственному,
+		// пока организация не удалена
+		check := func(want int) {
+			t.Helper()
+
+			tenders, err := repos.Tender.GetByTypePagination(
+				ctx, entity.Pagination{}, entity.TenderFilter{OrganizationId: org.Id}, user.Id,
+			)
+			requireNoError(t, err)
+			requireLen(t, tenders.Items, want)
+
+			my, err := repos.Bid.GetMyPagination(ctx, entity.Pagination{}, user.Id)
+			requireNoError(t, err)
+			requireLen(t, my.Items, want)
+
+			bids, err := repos.Bid.GetByTenderID(ctx, entity.Pagination{}, user.Id, tender.Id)
+			requireNoError(t, err)
+			requireLen(t, bids.Items, 2*want)
+
+			entries, err := repos.Audit.GetByEntity(ctx, tender.Id, user.Id, 0, 0)
+			requireNoError(t, err)
+			requireLen(t, entries, want)
+		}
+		check(1)
+		requireNoError(t, repos.Organization.Delete(ctx, org.Id))
+		check(0)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		org := newOrganization(t, repos, "org_"+unique())
+		for i := 0; i <= MaxPaginationLimit; i++ {
+			newResponsible(t, repos, org)
+		}
+
+		responsibles, err := repos.OrgResponsible.GetByOrganizationPagination(ctx, 0, 0, org.Id)
+		requireNoError(t, err)
+		requireLen(t, responsibles, DefaultPaginationLimit)
+
+		responsibles, err = repos.OrgResponsible.GetByOrganizationPagination(ctx, MaxPaginationLimit+10, 0, org.Id)
+		requireNoError(t, err)
+		requireLen(t, responsibles, MaxPaginationLimit)
+
+		responsibles, err = repos.OrgResponsible.GetByOrganizationPagination(
+			ctx, MaxPaginationLimit, MaxPaginationLimit, org.Id,
+		)
+		requireNoError(t, err)
+		requireLen(t, responsibles, 1)
+	})
+}
@@ -0,0 +1,71 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/repoerrs"
+)
+
+func testOrganization(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	t.Run("CreateGet", func(t *testing.T) {
+		org := newOrganization(t, repos, "org_"+unique())
+
+		got, err := repos.Organization.GetById(ctx, org.Id)
+		requireNoError(t, err)
+		if got.Name != org.Name || got.OrganizationType != org.OrganizationType {
+			t.Fatalf("GetById returned %+v, want %+v", got, org)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := repos.Organization.GetById(ctx, missingId())
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		_, err = repos.Organization.Update(ctx, missingId(), entity.Organization{Name: "name"})
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		requireError(t, repos.Organization.Delete(ctx, missingId()), repoerrs.ErrNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		org := newOrganization(t, repos, "org_"+unique())
+
+		requireNoError(t, repos.Organization.Delete(ctx, org.Id))
+		requireError(t, repos.Organization.Delete(ctx, org.Id), repoerrs.ErrNotFound)
+
+		_, err := repos.Organization.GetById(ctx, org.Id)
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		orgs, err := repos.Organization.GetPagination(ctx, 0, 0, org.Name)
+		requireNoError(t, err)
+		requireLen(t, orgs, 0)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		prefix := "page_" + unique()
+		for i := 0; i <= MaxPaginationLimit; i++ {
+			newOrganization(t, repos, fmt.Sprintf("%s_%02d", prefix, i))
+		}
+
+		orgs, err := repos.Organization.GetPagination(ctx, 0, 0, prefix)
+		requireNoError(t, err)
+		requireLen(t, orgs, DefaultPaginationLimit)
+		if orgs[0].Name != prefix+"_00" {
+			t.Fatalf("first organization is %s, want %s_00", orgs[0].Name, prefix)
+		}
+
+		orgs, err = repos.Organization.GetPagination(ctx, MaxPaginationLimit+10, 0, prefix)
+		requireNoError(t, err)
+		requireLen(t, orgs, MaxPaginationLimit)
+
+		orgs, err = repos.Organization.GetPagination(ctx, MaxPaginationLimit, MaxPaginationLimit, prefix)
+		requireNoError(t, err)
+		requireLen(t, orgs, 1)
+	})
+}
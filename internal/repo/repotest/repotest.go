@@ -0,0 +1,140 @@
+// Package repotest общий набор проверок реализаций repo.Repositories: каждое хранилище
+// должно одинаково создавать и находить записи, отображать ошибки в repoerrs, ограничивать страницы и вести версии.
+//
+// Подключается из теста хранилища:
+//
+//	func TestConformance(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) *repo.Repositories { return memory.NewRepositories() })
+//	}
+package repotest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+)
+
+// Границы пагинации, общие для всех хранилищ
+const (
+	MaxPaginationLimit     = 50
+	DefaultPaginationLimit = 5
+)
+
+// Factory возвращает репозитории хранилища для одной проверки. Хранилище не обязано быть пустым:
+// проверки создают записи с уникальными именами и выбирают только их
+type Factory func(t *testing.T) *repo.Repositories
+
+// Run запускает все проверки для хранилища newRepos
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("User", func(t *testing.T) { testUser(t, newRepos(t)) })
+	t.Run("Organization", func(t *testing.T) { testOrganization(t, newRepos(t)) })
+	t.Run("OrgResponsible", func(t *testing.T) { testOrgResponsible(t, newRepos(t)) })
+	t.Run("Tender", func(t *testing.T) { testTender(t, newRepos(t)) })
+	t.Run("Bid", func(t *testing.T) { testBid(t, newRepos(t)) })
+	t.Run("BidDecision", func(t *testing.T) { testBidDecision(t, newRepos(t)) })
+	t.Run("BidReview", func(t *testing.T) { testBidReview(t, newRepos(t)) })
+	t.Run("Webhook", func(t *testing.T) { testWebhook(t, newRepos(t)) })
+	t.Run("Audit", func(t *testing.T) { testAudit(t, newRepos(t)) })
+}
+
+// unique случайный суффикс, отделяющий записи проверки от остальных данных хранилища
+func unique() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// missingId UUID, которого нет в хранилище
+func missingId() string {
+	return "00000000-0000-4000-8000-" + unique() + "0000"
+}
+
+func requireNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func requireError(t *testing.T, err, want error) {
+	t.Helper()
+	if !errors.Is(err, want) {
+		t.Fatalf("got error %v, want %v", err, want)
+	}
+}
+
+func requireLen[T any](t *testing.T, items []T, want int) {
+	t.Helper()
+	if len(items) != want {
+		t.Fatalf("got %d items, want %d", len(items), want)
+	}
+}
+
+func newUser(t *testing.T, repos *repo.Repositories, username string) entity.User {
+	t.Helper()
+	ctx := context.Background()
+
+	id, err := repos.User.Create(ctx, entity.User{Username: username, FirstName: "First", LastName: "Last"})
+	requireNoError(t, err)
+
+	user, err := repos.User.GetById(ctx, id)
+	requireNoError(t, err)
+	return user
+}
+
+func newOrganization(t *testing.T, repos *repo.Repositories, name string) entity.Organization {
+	t.Helper()
+
+	org, err := repos.Organization.Create(
+		context.Background(), entity.Organization{Name: name, Description: "description", OrganizationType: "LLC"},
+	)
+	requireNoError(t, err)
+	return org
+}
+
+// newResponsible новый пользователь, ответственный за организацию org
+func newResponsible(t *testing.T, repos *repo.Repositories, org entity.Organization) entity.User {
+	t.Helper()
+
+	user := newUser(t, repos, "resp_"+unique())
+	_, err := repos.OrgResponsible.Create(
+		context.Background(), entity.OrgResponsible{OrganizationId: org.Id, UserId: user.Id},
+	)
+	requireNoError(t, err)
+	return user
+}
+
+func newTender(t *testing.T, repos *repo.Repositories, org entity.Organization, creator entity.User, name string) entity.Tender {
+	t.Helper()
+
+	tender, err := repos.Tender.Create(context.Background(), entity.Tender{
+		Name:            name,
+		Description:     "description",
+		ServiceType:     "Construction",
+		OrganizationId:  org.Id,
+		CreatorUsername: creator.Username,
+	})
+	requireNoError(t, err)
+	return tender
+}
+
+func newBid(t *testing.T, repos *repo.Repositories, tender entity.Tender, author entity.User, name string) entity.Bid {
+	t.Helper()
+
+	bid, err := repos.Bid.Create(context.Background(), entity.Bid{
+		Name:        name,
+		Description: "description",
+		TenderId:    tender.Id,
+		AuthorType:  entity.BidAuthorTypeUser,
+		AuthorId:    author.Id,
+	})
+	requireNoError(t, err)
+	return bid
+}
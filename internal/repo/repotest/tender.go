@@ -0,0 +1,147 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/repoerrs"
+)
+
+func testTender(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	org := newOrganization(t, repos, "org_"+unique())
+	creator := newResponsible(t, repos, org)
+
+	t.Run("CreateGet", func(t *testing.T) {
+		tender := newTender(t, repos, org, creator, "tender_"+unique())
+		if tender.Version != 1 || tender.Status != "Created" {
+			t.Fatalf("new tender has version %d and status %s, want 1 and Created", tender.Version, tender.Status)
+		}
+
+		got, err := repos.Tender.GetById(ctx, tender.Id)
+		requireNoError(t, err)
+		if got.Name != tender.Name || got.OrganizationId != org.Id || got.CreatorUsername != creator.Username {
+			t.Fatalf("GetById returned %+v, want %+v", got, tender)
+		}
+
+		versions, err := repos.Tender.GetVersions(ctx, tender.Id)
+		requireNoError(t, err)
+		requireLen(t, versions, 1)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		tender := newTender(t, repos, org, creator, "tender_"+unique())
+
+		_, err := repos.Tender.GetById(ctx, missingId())
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		requireError(t, repos.Tender.PutStatus(ctx, missingId(), "Published"), repoerrs.ErrNotFound)
+		requireError(t, repos.Tender.IncrementVersion(ctx, missingId(), 0), repoerrs.ErrNotFound)
+
+		_, err = repos.Tender.GetVersion(ctx, tender.Id, tender.Version+1)
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		requireError(t, repos.Tender.Rollback(ctx, tender.Id, tender.Version+1), repoerrs.ErrNotFound)
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		tender := newTender(t, repos, org, creator, "tender_"+unique())
+
+		requireNoError(t, repos.Tender.EditTender(ctx, entity.Tender{Name: "edited_" + unique()}, tender.Id))
+		requireNoError(t, repos.Tender.IncrementVersion(ctx, tender.Id, tender.Version))
+
+		err := repos.Tender.IncrementVersion(ctx, tender.Id, tender.Version)
+		requireError(t, err, repoerrs.ErrVersionConflict)
+		var conflict *repoerrs.VersionConflictError
+		if !errors.As(err, &conflict) || conflict.CurrentVersion != tender.Version+1 {
+			t.Fatalf("got error %v, want version conflict with current version %d", err, tender.Version+1)
+		}
+
+		requireNoError(t, repos.Tender.Rollback(ctx, tender.Id, 1))
+
+		got, err := repos.Tender.GetById(ctx, tender.Id)
+		requireNoError(t, err)
+		if got.Version != 3 || got.Name != tender.Name {
+			t.Fatalf("after rollback got version %d and name %s, want 3 and %s", got.Version, got.Name, tender.Name)
+		}
+
+		versions, err := repos.Tender.GetVersions(ctx, tender.Id)
+		requireNoError(t, err)
+		requireLen(t, versions, 3)
+		for i, v := range versions {
+			if v.Version != i+1 {
+				t.Fatalf("version %d at position %d", v.Version, i)
+			}
+		}
+
+		edited, err := repos.Tender.GetVersion(ctx, tender.Id, 2)
+		requireNoError(t, err)
+		if edited.Name == tender.Name {
+			t.Fatalf("version 2 has original name %s", edited.Name)
+		}
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		tender := newTender(t, repos, org, creator, "tender_"+unique())
+
+		requireNoError(t, repos.Tender.PutStatus(ctx, tender.Id, "Published"))
+
+		got, err := repos.Tender.GetById(ctx, tender.Id)
+		requireNoError(t, err)
+		if got.Status != "Published" || got.Version != tender.Version {
+			t.Fatalf("after PutStatus got status %s and version %d, want Published and %d",
+				got.Status, got.Version, tender.Version)
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		org := newOrganization(t, repos, "org_"+unique())
+		creator := newResponsible(t, repos, org)
+		for i := 0; i <= MaxPaginationLimit; i++ {
+			newTender(t, repos, org, creator, fmt.Sprintf("tender_%02d", i))
+		}
+
+		page, err := repos.Tender.GetMyPagination(ctx, entity.Pagination{}, creator.Username)
+		requireNoError(t, err)
+		requireLen(t, page.Items, DefaultPaginationLimit)
+		if page.Next == nil {
+			t.Fatalf("first page has no next cursor")
+		}
+
+		page, err = repos.Tender.GetMyPagination(ctx, entity.Pagination{Limit: MaxPaginationLimit + 10}, creator.Username)
+		requireNoError(t, err)
+		requireLen(t, page.Items, MaxPaginationLimit)
+		if page.Items[0].Name != "tender_00" {
+			t.Fatalf("first tender is %s, want tender_00", page.Items[0].Name)
+		}
+
+		last, err := repos.Tender.GetMyPagination(
+			ctx, entity.Pagination{Limit: MaxPaginationLimit, After: page.Next}, creator.Username,
+		)
+		requireNoError(t, err)
+		requireLen(t, last.Items, 1)
+		if last.Next != nil {
+			t.Fatalf("last page has next cursor")
+		}
+
+		last, err = repos.Tender.GetMyPagination(
+			ctx, entity.Pagination{Limit: MaxPaginationLimit, Offset: MaxPaginationLimit}, creator.Username,
+		)
+		requireNoError(t, err)
+		requireLen(t, last.Items, 1)
+
+		page, err = repos.Tender.GetByTypePagination(
+			ctx, entity.Pagination{WithTotal: true}, entity.TenderFilter{OrganizationId: org.Id}, creator.Id,
+		)
+		requireNoError(t, err)
+		requireLen(t, page.Items, DefaultPaginationLimit)
+		if page.Total == nil || *page.Total != MaxPaginationLimit+1 {
+			t.Fatalf("got total %v, want %d", page.Total, MaxPaginationLimit+1)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/repoerrs"
+)
+
+func testUser(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	t.Run("CreateGet", func(t *testing.T) {
+		user := newUser(t, repos, "user_"+unique())
+		if !user.IsActive() {
+			t.Fatalf("new user is deactivated")
+		}
+
+		got, err := repos.User.GetByUsername(ctx, user.Username)
+		requireNoError(t, err)
+		if got.Id != user.Id {
+			t.Fatalf("GetByUsername returned %s, want %s", got.Id, user.Id)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := repos.User.GetById(ctx, missingId())
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		_, err = repos.User.GetByUsername(ctx, "missing_"+unique())
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		_, err = repos.User.Update(ctx, missingId(), entity.User{FirstName: "First"})
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		requireError(t, repos.User.Deactivate(ctx, missingId()), repoerrs.ErrNotFound)
+	})
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		user := newUser(t, repos, "user_"+unique())
+
+		_, err := repos.User.Create(ctx, entity.User{Username: user.Username})
+		requireError(t, err, repoerrs.ErrAlreadyExists)
+	})
+
+	t.Run("Deactivate", func(t *testing.T) {
+		user := newUser(t, repos, "user_"+unique())
+
+		requireNoError(t, repos.User.Deactivate(ctx, user.Id))
+		requireError(t, repos.User.Deactivate(ctx, user.Id), repoerrs.ErrNotFound)
+
+		_, err := repos.User.Update(ctx, user.Id, entity.User{FirstName: "First"})
+		requireError(t, err, repoerrs.ErrNotFound)
+
+		got, err := repos.User.GetById(ctx, user.Id)
+		requireNoError(t, err)
+		if got.IsActive() {
+			t.Fatalf("deactivated user is active")
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		prefix := "page_" + unique()
+		for i := 0; i <= MaxPaginationLimit; i++ {
+			newUser(t, repos, fmt.Sprintf("%s_%02d", prefix, i))
+		}
+
+		users, err := repos.User.GetPagination(ctx, 0, 0, prefix)
+		requireNoError(t, err)
+		requireLen(t, users, DefaultPaginationLimit)
+		if users[0].Username != prefix+"_00" {
+			t.Fatalf("first user is %s, want %s_00", users[0].Username, prefix)
+		}
+
+		users, err = repos.User.GetPagination(ctx, MaxPaginationLimit+10, 0, prefix)
+		requireNoError(t, err)
+		requireLen(t, users, MaxPaginationLimit)
+
+		users, err = repos.User.GetPagination(ctx, MaxPaginationLimit, MaxPaginationLimit, prefix)
+		requireNoError(t, err)
+		requireLen(t, users, 1)
+	})
+}
@@ -0,0 +1,47 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/repoerrs"
+)
+
+func testWebhook(t *testing.T, repos *repo.Repositories) {
+	ctx := context.Background()
+
+	org := newOrganization(t, repos, "org_"+unique())
+
+	webhook, err := repos.Webhook.Create(ctx, entity.Webhook{
+		OrganizationId: org.Id,
+		Url:            "http://localhost/hook",
+		Secret:         "secret",
+		EventTypes:     []string{string(entity.EventTenderPublished)},
+	})
+	requireNoError(t, err)
+
+	got, err := repos.Webhook.GetById(ctx, webhook.Id)
+	requireNoError(t, err)
+	if got.Url != webhook.Url || got.Secret != webhook.Secret || len(got.EventTypes) != 1 {
+		t.Fatalf("GetById returned %+v, want %+v", got, webhook)
+	}
+
+	webhooks, err := repos.Webhook.GetByOrganization(ctx, org.Id)
+	requireNoError(t, err)
+	requireLen(t, webhooks, 1)
+
+	_, err = repos.Webhook.GetById(ctx, missingId())
+	requireError(t, err, repoerrs.ErrNotFound)
+
+	// подписку удаляет только ее организация
+	other := newOrganization(t, repos, "org_"+unique())
+	requireError(t, repos.Webhook.Delete(ctx, other.Id, webhook.Id), repoerrs.ErrNotFound)
+
+	requireNoError(t, repos.Webhook.Delete(ctx, org.Id, webhook.Id))
+	requireError(t, repos.Webhook.Delete(ctx, org.Id, webhook.Id), repoerrs.ErrNotFound)
+
+	_, err = repos.Webhook.GetById(ctx, webhook.Id)
+	requireError(t, err, repoerrs.ErrNotFound)
+}
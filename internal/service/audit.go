@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+)
+
+type AuditService struct {
+	auditRepo repo.Audit
+}
+
+func NewAuditService(auditRepo repo.Audit) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// GetByEntity журнал тендера или предложения, видимый ответственному за организацию тендера
+func (s *AuditService) GetByEntity(
+	ctx context.Context, log *slog.Logger, input AuditGetByEntityInput,
+) ([]entity.AuditEntry, error) {
+	output, err := s.auditRepo.GetByEntity(ctx, input.EntityId, input.UserId, input.Limit, input.Offset)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - AuditService - GetByEntity: %v", err))
+		return nil, ErrCannotGetAudit
+	}
+	return output, nil
+}
@@ -2,20 +2,49 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
+	"tender-service/internal/authz"
 	"tender-service/internal/entity"
+	"tender-service/internal/metrics"
 	"tender-service/internal/repo"
 	"tender-service/internal/repo/repoerrs"
 )
 
+// decisionQuorumLimit максимальное количество одобрений, необходимое для принятия предложения
+const decisionQuorumLimit = 3
+
+const (
+	bidStatusApproved   = "Approved"
+	bidStatusRejected   = "Rejected"
+	bidDecisionApproved = "Approved"
+)
+
 type BidService struct {
-	bidRepo repo.Bid
+	bidRepo         repo.Bid
+	bidDecisionRepo repo.BidDecision
+	tenderRepo      repo.Tender
+	txManager       repo.TxManager
+	policy          *authz.Policy
+	searchLanguage  string
+	metrics         *metrics.Metrics
 }
 
-func NewBidService(bidRepo repo.Bid) *BidService {
-	return &BidService{bidRepo: bidRepo}
+func NewBidService(
+	bidRepo repo.Bid, bidDecisionRepo repo.BidDecision, tenderRepo repo.Tender, txManager repo.TxManager,
+	policy *authz.Policy, searchLanguage string, metrics *metrics.Metrics,
+) *BidService {
+	return &BidService{
+		bidRepo:         bidRepo,
+		bidDecisionRepo: bidDecisionRepo,
+		tenderRepo:      tenderRepo,
+		txManager:       txManager,
+		policy:          policy,
+		searchLanguage:  searchLanguage,
+		metrics:         metrics,
+	}
 }
 
 func (s *BidService) Create(
@@ -29,7 +58,13 @@ func (s *BidService) Create(
 		AuthorType:  input.AuthorType,
 		AuthorId:    input.AuthorId,
 	}
-	output, err := s.bidRepo.Create(ctx, bid)
+	var output entity.Bid
+	// предложение, снимок истории и событие outbox сохраняются вместе
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		output, err = s.bidRepo.Create(ctx, bid)
+		return err
+	})
 	if err != nil {
 		if err == repoerrs.ErrAlreadyExists {
 			return entity.Bid{}, ErrBidAlreadyExists
@@ -38,16 +73,20 @@ func (s *BidService) Create(
 		return entity.Bid{}, ErrCannotGetBid
 	}
 	log.Info(fmt.Sprintf("Service - BidService - Create - id: %s", output.Id))
+	s.metrics.BidCreated()
 	return output, nil
 }
 
 func (s *BidService) GetByTenderId(
 	ctx context.Context, log *slog.Logger, input BidGetByTenderIdInput,
-) ([]entity.Bid, error) {
-	output, err := s.bidRepo.GetByTenderID(ctx, input.Limit, input.Offset, input.UserId, input.TenderId)
+) (entity.Page[entity.Bid], error) {
+	output, err := s.bidRepo.GetByTenderID(
+		ctx, entity.Pagination{Limit: input.Limit, Offset: input.Offset, After: input.After},
+		input.UserId, input.TenderId,
+	)
 	if err != nil {
 		log.Error(fmt.Sprintf("Service - BidService - GetByTenderId: %v", err))
-		return nil, ErrCannotGetBid
+		return entity.Page[entity.Bid]{}, ErrCannotGetBid
 	}
 	return output, nil
 }
@@ -57,6 +96,9 @@ func (s *BidService) GetById(
 ) (entity.Bid, error) {
 	output, err := s.bidRepo.GetById(ctx, bidId)
 	if err != nil {
+		if err == repoerrs.ErrNotFound {
+			return entity.Bid{}, ErrBidNotFound
+		}
 		log.Error(fmt.Sprintf("Service - BidService - GetById: %v", err))
 		return entity.Bid{}, ErrCannotGetBid
 	}
@@ -65,33 +107,75 @@ func (s *BidService) GetById(
 
 func (s *BidService) GetMy(
 	ctx context.Context, log *slog.Logger, input BidGetMyInput,
-) ([]entity.Bid, error) {
+) (entity.Page[entity.Bid], error) {
 	//log.Info(fmt.Sprintf("limit - %d offset - %d", input.Limit, input.Offset))
-	output, err := s.bidRepo.GetMyPagination(ctx, input.Limit, input.Offset, input.UserId)
+	output, err := s.bidRepo.GetMyPagination(
+		ctx, entity.Pagination{Limit: input.Limit, Offset: input.Offset, After: input.After}, input.UserId,
+	)
 	if err != nil {
 		log.Error(fmt.Sprintf("Service - BidService - GetMy: %v", err))
-		return nil, ErrCannotGetBid
+		return entity.Page[entity.Bid]{}, ErrCannotGetBid
 	}
 	return output, nil
 }
 
-func (s *BidService) PutStatus(ctx context.Context, log *slog.Logger, bidId, status string) (entity.Bid, error) {
-	err := s.bidRepo.PutStatus(ctx, bidId, status)
+func (s *BidService) Search(
+	ctx context.Context, log *slog.Logger, input BidSearchInput,
+) ([]entity.Bid, error) {
+	output, err := s.bidRepo.Search(
+		ctx, entity.Pagination{Limit: input.Limit, Offset: input.Offset}, s.searchLanguage, input.Query, input.UserId,
+	)
 	if err != nil {
-		log.Error(fmt.Sprintf("Service - BidService - PutStatus: %v", err))
-		return entity.Bid{}, ErrCannotPutStatus
+		log.Error(fmt.Sprintf("Service - BidService - Search: %v", err))
+		return nil, ErrCannotGetBid
 	}
+	return output, nil
+}
 
-	err = s.bidRepo.IncrementVersion(ctx, bidId)
+// requireUndecided принятое или отклоненное предложение больше не меняется: решение кворума окончательное
+func (s *BidService) requireUndecided(ctx context.Context, bidId string) error {
+	b, err := s.bidRepo.GetById(ctx, bidId)
 	if err != nil {
-		log.Error(fmt.Sprintf("Service - BidService - IncrementVersion: %v", err))
-		return entity.Bid{}, ErrCannotIncrement
+		return fmt.Errorf("GetById: %w", err)
 	}
+	if b.Status == bidStatusApproved || b.Status == bidStatusRejected {
+		return ErrBidDecided
+	}
+	return nil
+}
 
-	output, err := s.GetById(ctx, log, bidId)
+// PutStatus при expectedVersion > 0 меняет статус, только если версия предложения не изменилась
+func (s *BidService) PutStatus(
+	ctx context.Context, log *slog.Logger, bidId, status string, expectedVersion int,
+) (entity.Bid, error) {
+	var output entity.Bid
+	// статус, версия и снимок истории меняются вместе
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.requireUndecided(ctx, bidId); err != nil {
+			return err
+		}
+		if err := s.bidRepo.PutStatus(ctx, bidId, status); err != nil {
+			return fmt.Errorf("PutStatus: %w", err)
+		}
+		if err := s.bidRepo.IncrementVersion(ctx, bidId, expectedVersion); err != nil {
+			return fmt.Errorf("IncrementVersion: %w", err)
+		}
+
+		var err error
+		if output, err = s.bidRepo.GetById(ctx, bidId); err != nil {
+			return fmt.Errorf("GetById: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		log.Error(fmt.Sprintf("Service - BidService - GetById: %v", err))
-		return entity.Bid{}, ErrCannotGetBid
+		if errors.Is(err, ErrBidDecided) {
+			return entity.Bid{}, ErrBidDecided
+		}
+		if conflict := versionConflict(err); conflict != nil {
+			return entity.Bid{}, conflict
+		}
+		log.Error(fmt.Sprintf("Service - BidService - PutStatus: %v", err))
+		return entity.Bid{}, ErrCannotPutStatus
 	}
 	return output, nil
 }
@@ -99,28 +183,175 @@ func (s *BidService) PutStatus(ctx context.Context, log *slog.Logger, bidId, sta
 func (s *BidService) EditBid(ctx context.Context, log *slog.Logger, input BidEditInput, bidId string) (
 	entity.Bid, error,
 ) {
-	log.Info("EditBid")
-	var err error
 	in := entity.Bid{
 		Name:        input.Name,
 		Description: input.Description,
 	}
 
-	if err = s.bidRepo.EditBid(ctx, in, bidId); err != nil {
+	var output entity.Bid
+	// правка, версия и снимок истории меняются вместе
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.requireUndecided(ctx, bidId); err != nil {
+			return err
+		}
+		if err := s.bidRepo.EditBid(ctx, in, bidId); err != nil {
+			return fmt.Errorf("EditBid: %w", err)
+		}
+		if err := s.bidRepo.IncrementVersion(ctx, bidId, input.ExpectedVersion); err != nil {
+			return fmt.Errorf("IncrementVersion: %w", err)
+		}
+
+		var err error
+		if output, err = s.bidRepo.GetById(ctx, bidId); err != nil {
+			return fmt.Errorf("GetById: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrBidDecided) {
+			return entity.Bid{}, ErrBidDecided
+		}
+		if conflict := versionConflict(err); conflict != nil {
+			return entity.Bid{}, conflict
+		}
 		log.Error(fmt.Sprintf("Service - BidService - EditBid: %v", err))
 		return entity.Bid{}, ErrCannotEditBid
 	}
+	return output, nil
+}
 
-	err = s.bidRepo.IncrementVersion(ctx, bidId)
+func (s *BidService) Rollback(
+	ctx context.Context, log *slog.Logger, bidId string, version int,
+) (entity.Bid, error) {
+	var output entity.Bid
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.requireUndecided(ctx, bidId); err != nil {
+			return err
+		}
+		if err := s.bidRepo.Rollback(ctx, bidId, version); err != nil {
+			return err
+		}
+
+		var err error
+		if output, err = s.bidRepo.GetById(ctx, bidId); err != nil {
+			return fmt.Errorf("GetById: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		log.Error(fmt.Sprintf("Service - BidService - IncrementVersion: %v", err))
-		return entity.Bid{}, ErrCannotIncrement
+		if err == ErrBidDecided {
+			return entity.Bid{}, ErrBidDecided
+		}
+		if err == repoerrs.ErrNotFound {
+			return entity.Bid{}, ErrBidVersionNotFound
+		}
+		log.Error(fmt.Sprintf("Service - BidService - Rollback: %v", err))
+		return entity.Bid{}, ErrCannotRollback
 	}
+	return output, nil
+}
 
-	outputNew, err := s.GetById(ctx, log, bidId)
+func (s *BidService) GetVersions(
+	ctx context.Context, log *slog.Logger, bidId string,
+) ([]entity.Bid, error) {
+	output, err := s.bidRepo.GetVersions(ctx, bidId)
 	if err != nil {
-		log.Error(fmt.Sprintf("Service - BidService - GetById: %v", err))
-		return entity.Bid{}, ErrCannotGetBid
+		log.Error(fmt.Sprintf("Service - BidService - GetVersions: %v", err))
+		return nil, ErrCannotGetBid
+	}
+	return output, nil
+}
+
+func (s *BidService) Diff(
+	ctx context.Context, log *slog.Logger, bidId string, from, to int,
+) ([]entity.FieldDiff, error) {
+	var versions [2]entity.Bid
+	for i, version := range []int{from, to} {
+		b, err := s.bidRepo.GetVersion(ctx, bidId, version)
+		if err != nil {
+			if err == repoerrs.ErrNotFound {
+				return nil, ErrBidVersionNotFound
+			}
+			log.Error(fmt.Sprintf("Service - BidService - Diff: %v", err))
+			return nil, ErrCannotGetBid
+		}
+		versions[i] = b
 	}
-	return outputNew, nil
+
+	return diffFields(
+		[]fieldPair{
+			{"name", versions[0].Name, versions[1].Name},
+			{"description", versions[0].Description, versions[1].Description},
+			{"status", versions[0].Status, versions[1].Status},
+		},
+	), nil
+}
+
+func (s *BidService) SubmitDecision(
+	ctx context.Context, log *slog.Logger, input BidSubmitDecisionInput,
+) (BidSubmitDecisionOutput, error) {
+	decision := entity.BidDecision{
+		BidId:    input.BidId,
+		UserId:   input.User.Id,
+		Decision: input.Decision,
+	}
+
+	var output BidSubmitDecisionOutput
+	// проверка прав, решение и чтение версий до и после идут одной транзакцией
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		output = BidSubmitDecisionOutput{}
+
+		bid, err := s.bidRepo.GetById(ctx, input.BidId)
+		if err != nil {
+			return fmt.Errorf("GetById: %w", err)
+		}
+		if err = s.policy.CanDecideBid(ctx, input.User, bid); err != nil {
+			return fmt.Errorf("CanDecideBid: %w", err)
+		}
+		output.BidOldVersion = bid.Version
+
+		// версия тендера до решения нужна для журнала аудита, если решение его закроет
+		t, err := s.tenderRepo.GetById(ctx, bid.TenderId)
+		if err != nil {
+			return fmt.Errorf("tenderRepo.GetById: %w", err)
+		}
+		if err = s.bidDecisionRepo.Submit(ctx, decision, decisionQuorumLimit); err != nil {
+			return fmt.Errorf("Submit: %w", err)
+		}
+
+		if output.Bid, err = s.bidRepo.GetById(ctx, input.BidId); err != nil {
+			return fmt.Errorf("GetById: %w", err)
+		}
+		// одобрение, набравшее кворум, принимает предложение и закрывает тендер
+		if input.Decision == bidDecisionApproved && output.Bid.Status == bidStatusApproved {
+			closed, err := s.tenderRepo.GetById(ctx, bid.TenderId)
+			if err != nil {
+				return fmt.Errorf("tenderRepo.GetById: %w", err)
+			}
+			output.TenderClosed = true
+			output.TenderOldVersion = t.Version
+			output.TenderNewVersion = closed.Version
+		}
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repoerrs.ErrNotFound):
+			return BidSubmitDecisionOutput{}, ErrBidNotFound
+		case errors.Is(err, authz.ErrForbidden):
+			return BidSubmitDecisionOutput{}, ErrForbidden
+		case errors.Is(err, repoerrs.ErrAlreadyExists):
+			return BidSubmitDecisionOutput{}, ErrBidDecisionAlreadyExists
+		case errors.Is(err, repoerrs.ErrInvalidStatus):
+			return BidSubmitDecisionOutput{}, ErrBidNotDecidable
+		}
+		log.Error(fmt.Sprintf("Service - BidService - SubmitDecision: %v", err))
+		return BidSubmitDecisionOutput{}, ErrCannotSubmitDecision
+	}
+	log.Info(fmt.Sprintf("Service - BidService - SubmitDecision - bid: %s - %s", input.BidId, input.Decision))
+	s.metrics.BidDecision(input.Decision)
+	if output.TenderClosed {
+		s.metrics.TenderClosed()
+	}
+	return output, nil
 }
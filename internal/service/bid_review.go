@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"tender-service/internal/authz"
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/repoerrs"
+)
+
+type BidReviewService struct {
+	bidReviewRepo repo.BidReview
+	bidRepo       repo.Bid
+	tenderRepo    repo.Tender
+	policy        *authz.Policy
+}
+
+func NewBidReviewService(
+	bidReviewRepo repo.BidReview, bidRepo repo.Bid, tenderRepo repo.Tender, policy *authz.Policy,
+) *BidReviewService {
+	return &BidReviewService{bidReviewRepo: bidReviewRepo, bidRepo: bidRepo, tenderRepo: tenderRepo, policy: policy}
+}
+
+func (s *BidReviewService) Create(
+	ctx context.Context, log *slog.Logger, input BidReviewCreateInput,
+) (entity.BidReview, error) {
+	log.Info(fmt.Sprintf("Service - BidReviewService - Create"))
+	bid, err := s.bidRepo.GetById(ctx, input.BidId)
+	if err != nil {
+		if err == repoerrs.ErrNotFound {
+			return entity.BidReview{}, ErrBidNotFound
+		}
+		log.Error(fmt.Sprintf("Service - BidReviewService - bidRepo.GetById: %v", err))
+		return entity.BidReview{}, ErrCannotCreateBidReview
+	}
+
+	if err = s.policy.CanDecideBid(ctx, input.User, bid); err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			return entity.BidReview{}, ErrForbidden
+		}
+		log.Error(fmt.Sprintf("Service - BidReviewService - CanDecideBid: %v", err))
+		return entity.BidReview{}, ErrCannotCreateBidReview
+	}
+
+	tender, err := s.tenderRepo.GetById(ctx, bid.TenderId)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - BidReviewService - tenderRepo.GetById: %v", err))
+		return entity.BidReview{}, ErrCannotCreateBidReview
+	}
+
+	review := entity.BidReview{
+		Description:    input.Description,
+		BidId:          input.BidId,
+		UserId:         input.User.Id,
+		OrganizationId: tender.OrganizationId,
+	}
+	output, err := s.bidReviewRepo.Create(ctx, review)
+	if err != nil {
+		if err == repoerrs.ErrAlreadyExists {
+			return entity.BidReview{}, ErrBidReviewAlreadyExists
+		}
+		log.Error(fmt.Sprintf("Service - BidReviewService - Create: %v", err))
+		return entity.BidReview{}, ErrCannotCreateBidReview
+	}
+	log.Info(fmt.Sprintf("Service - BidReviewService - bidReviewRepo.Create - id: %s", output.Id))
+	return output, nil
+}
+
+// GetByAuthor отзывы на предложения автора по всем тендерам; ErrBidNotFound, если у автора нет предложения на тендер
+func (s *BidReviewService) GetByAuthor(
+	ctx context.Context, log *slog.Logger, input BidReviewGetByAuthorInput,
+) ([]entity.BidReview, error) {
+	exists, err := s.bidRepo.ExistsByAuthor(ctx, input.TenderId, input.AuthorId)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - BidReviewService - bidRepo.ExistsByAuthor: %v", err))
+		return nil, ErrCannotGetBidReview
+	}
+	if !exists {
+		return nil, ErrBidNotFound
+	}
+
+	output, err := s.bidReviewRepo.GetByAuthorPagination(
+		ctx, input.Limit, input.Offset, input.AuthorId, input.OrganizationId,
+	)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - BidReviewService - GetByAuthor: %v", err))
+		return nil, ErrCannotGetBidReview
+	}
+	return output, nil
+}
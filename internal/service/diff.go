@@ -0,0 +1,27 @@
+package service
+
+import "tender-service/internal/entity"
+
+type fieldPair struct {
+	field string
+	from  string
+	to    string
+}
+
+// diffFields возвращает только те поля, значения которых отличаются между версиями
+func diffFields(pairs []fieldPair) []entity.FieldDiff {
+	output := make([]entity.FieldDiff, 0, len(pairs))
+	for _, p := range pairs {
+		if p.from == p.to {
+			continue
+		}
+		output = append(
+			output, entity.FieldDiff{
+				Field: p.field,
+				From:  p.from,
+				To:    p.to,
+			},
+		)
+	}
+	return output
+}
@@ -1,22 +1,42 @@
 package service
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"tender-service/internal/repo/repoerrs"
+)
 
 var (
-	ErrUserAlreadyExists = fmt.Errorf("user already exists")
-	ErrCannotCreateUser  = fmt.Errorf("cannot create user")
-	ErrUserNotFound      = fmt.Errorf("user not found")
-	ErrCannotGetUser     = fmt.Errorf("cannot get user")
+	ErrForbidden = fmt.Errorf("forbidden")
+
+	ErrUserAlreadyExists    = fmt.Errorf("user already exists")
+	ErrCannotCreateUser     = fmt.Errorf("cannot create user")
+	ErrUserNotFound         = fmt.Errorf("user not found")
+	ErrCannotGetUser        = fmt.Errorf("cannot get user")
+	ErrCannotUpdateUser     = fmt.Errorf("cannot update user")
+	ErrUserDeactivated      = fmt.Errorf("user is deactivated")
+	ErrCannotDeactivateUser = fmt.Errorf("cannot deactivate user")
 
 	ErrOrgAlreadyExists = fmt.Errorf("organization already exists")
 	ErrCannotCreateOrg  = fmt.Errorf("cannot create organization")
 	ErrOrgNotFound      = fmt.Errorf("organization not found")
 	ErrCannotGetOrg     = fmt.Errorf("cannot get organization")
+	ErrCannotUpdateOrg  = fmt.Errorf("cannot update organization")
+	ErrCannotDeleteOrg  = fmt.Errorf("cannot delete organization")
+
+	ErrOrgRespAlreadyExists     = fmt.Errorf("organization responsible already exists")
+	ErrUserResponsibleElsewhere = fmt.Errorf("user is already responsible for another organization")
+	ErrCannotCreateOrgResp      = fmt.Errorf("cannot create organization responsible")
+	ErrOrgRespNotFound          = fmt.Errorf("organization responsible not found")
+	ErrCannotGetOrgResp         = fmt.Errorf("cannot get organization responsible")
+	ErrCannotDeleteOrgResp      = fmt.Errorf("cannot delete organization responsible")
+	ErrLastOrgResponsible       = fmt.Errorf("cannot delete the last organization responsible")
 
-	ErrOrgRespAlreadyExists = fmt.Errorf("organization responsible already exists")
-	ErrCannotCreateOrgResp  = fmt.Errorf("cannot create organization responsible")
-	ErrOrgRespNotFound      = fmt.Errorf("organization responsible not found")
-	ErrCannotGetOrgResp     = fmt.Errorf("cannot get organization responsible")
+	ErrWebhookNotFound     = fmt.Errorf("webhook not found")
+	ErrCannotCreateWebhook = fmt.Errorf("cannot create webhook")
+	ErrCannotGetWebhook    = fmt.Errorf("cannot get webhook")
+	ErrCannotDeleteWebhook = fmt.Errorf("cannot delete webhook")
 
 	ErrTenderAlreadyExists = fmt.Errorf("tender already exists")
 	ErrCannotCreateTender  = fmt.Errorf("cannot create tender")
@@ -25,10 +45,49 @@ var (
 	ErrCannotPutStatus     = fmt.Errorf("cannot put status")
 	ErrCannotEditTender    = fmt.Errorf("cannot edit tender")
 	ErrCannotIncrement     = fmt.Errorf("cannot incremet")
+	ErrVersionConflict     = fmt.Errorf("version conflict")
+
+	ErrTenderVersionNotFound = fmt.Errorf("tender version not found")
+	ErrCannotRollback        = fmt.Errorf("cannot rollback")
 
 	ErrBidAlreadyExists = fmt.Errorf("tender already exists")
 	ErrCannotCreateBid  = fmt.Errorf("cannot create tender")
 	ErrBidNotFound      = fmt.Errorf("tender not found")
 	ErrCannotGetBid     = fmt.Errorf("cannot get tender")
 	ErrCannotEditBid    = fmt.Errorf("cannot edit tender")
+
+	ErrBidVersionNotFound = fmt.Errorf("bid version not found")
+
+	ErrBidDecisionAlreadyExists = fmt.Errorf("bid decision already exists")
+	ErrBidNotDecidable          = fmt.Errorf("bid is not open for decisions")
+	ErrBidDecided               = fmt.Errorf("bid is already approved or rejected")
+	ErrCannotSubmitDecision     = fmt.Errorf("cannot submit decision")
+
+	ErrBidReviewAlreadyExists = fmt.Errorf("bid review already exists")
+	ErrCannotCreateBidReview  = fmt.Errorf("cannot create bid review")
+	ErrCannotGetBidReview     = fmt.Errorf("cannot get bid review")
+
+	ErrCannotGetAudit = fmt.Errorf("cannot get audit log")
 )
+
+// VersionConflictError ErrVersionConflict с текущей версией тендера или предложения для ответа 409
+type VersionConflictError struct {
+	CurrentVersion int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%v: current version %d", ErrVersionConflict, e.CurrentVersion)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// versionConflict переводит конфликт версий репозитория в ошибку сервиса, остальные ошибки - nil
+func versionConflict(err error) error {
+	var conflict *repoerrs.VersionConflictError
+	if errors.As(err, &conflict) {
+		return &VersionConflictError{CurrentVersion: conflict.CurrentVersion}
+	}
+	return nil
+}
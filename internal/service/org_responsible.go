@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -12,10 +13,11 @@ import (
 
 type OrgResponsibleService struct {
 	orgRespRepo repo.OrgResponsible
+	txManager   repo.TxManager
 }
 
-func NewOrgResponsibleService(orgRespRepo repo.OrgResponsible) *OrgResponsibleService {
-	return &OrgResponsibleService{orgRespRepo: orgRespRepo}
+func NewOrgResponsibleService(orgRespRepo repo.OrgResponsible, txManager repo.TxManager) *OrgResponsibleService {
+	return &OrgResponsibleService{orgRespRepo: orgRespRepo, txManager: txManager}
 }
 
 func (s *OrgResponsibleService) Create(
@@ -31,6 +33,9 @@ func (s *OrgResponsibleService) Create(
 		if err == repoerrs.ErrAlreadyExists {
 			return entity.OrgResponsible{}, ErrOrgRespAlreadyExists
 		}
+		if err == repoerrs.ErrConflict {
+			return entity.OrgResponsible{}, ErrUserResponsibleElsewhere
+		}
 		log.Error(fmt.Sprintf("Service - OrgResponsibleService - Create: %v", err))
 		return entity.OrgResponsible{}, ErrCannotCreateOrgResp
 	}
@@ -69,3 +74,49 @@ func (s *OrgResponsibleService) GetByIds(
 	}
 	return output, nil
 }
+
+func (s *OrgResponsibleService) GetByOrganization(
+	ctx context.Context, log *slog.Logger, input OrgResponsibleGetByOrganizationInput,
+) ([]entity.OrgResponsible, error) {
+	output, err := s.orgRespRepo.GetByOrganizationPagination(ctx, input.Limit, input.Offset, input.OrganizationId)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - OrgResponsibleService - GetByOrganization: %v", err))
+		return nil, ErrCannotGetOrgResp
+	}
+	return output, nil
+}
+
+// Delete снимает ответственного; последнего ответственного снять нельзя, иначе организацией некому управлять
+func (s *OrgResponsibleService) Delete(ctx context.Context, log *slog.Logger, input OrgResponsibleDeleteInput) error {
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		err := s.orgRespRepo.Delete(
+			ctx, entity.OrgResponsible{
+				OrganizationId: input.OrganizationId,
+				UserId:         input.UserId,
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		left, err := s.orgRespRepo.GetByOrganizationPagination(ctx, 1, 0, input.OrganizationId)
+		if err != nil {
+			return fmt.Errorf("GetByOrganizationPagination: %w", err)
+		}
+		if len(left) == 0 {
+			return ErrLastOrgResponsible
+		}
+		return nil
+	})
+	if err != nil {
+		if err == repoerrs.ErrNotFound {
+			return ErrOrgRespNotFound
+		}
+		if errors.Is(err, ErrLastOrgResponsible) {
+			return ErrLastOrgResponsible
+		}
+		log.Error(fmt.Sprintf("Service - OrgResponsibleService - Delete: %v", err))
+		return ErrCannotDeleteOrgResp
+	}
+	return nil
+}
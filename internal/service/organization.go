@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -12,10 +13,14 @@ import (
 
 type OrganizationService struct {
 	organizationRepo repo.Organization
+	orgRespRepo      repo.OrgResponsible
+	txManager        repo.TxManager
 }
 
-func NewOrganizationService(organizationRepo repo.Organization) *OrganizationService {
-	return &OrganizationService{organizationRepo: organizationRepo}
+func NewOrganizationService(
+	organizationRepo repo.Organization, orgRespRepo repo.OrgResponsible, txManager repo.TxManager,
+) *OrganizationService {
+	return &OrganizationService{organizationRepo: organizationRepo, orgRespRepo: orgRespRepo, txManager: txManager}
 }
 
 func (s *OrganizationService) Create(
@@ -27,11 +32,26 @@ func (s *OrganizationService) Create(
 		Description:      input.Description,
 		OrganizationType: input.OrganizationType,
 	}
-	output, err := s.organizationRepo.Create(ctx, organization)
+	var output entity.Organization
+	// организация без ответственных недоступна для управления, поэтому создатель добавляется в той же транзакции
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if output, err = s.organizationRepo.Create(ctx, organization); err != nil {
+			return fmt.Errorf("organizationRepo.Create: %w", err)
+		}
+		_, err = s.orgRespRepo.Create(ctx, entity.OrgResponsible{OrganizationId: output.Id, UserId: input.CreatorId})
+		if err != nil {
+			return fmt.Errorf("orgRespRepo.Create: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		if err == repoerrs.ErrAlreadyExists {
+		if errors.Is(err, repoerrs.ErrAlreadyExists) {
 			return entity.Organization{}, ErrOrgAlreadyExists
 		}
+		if errors.Is(err, repoerrs.ErrConflict) {
+			return entity.Organization{}, ErrUserResponsibleElsewhere
+		}
 		log.Error(fmt.Sprintf("Service - OrganizationService - Create: %v", err))
 		return entity.Organization{}, ErrCannotCreateOrg
 	}
@@ -52,3 +72,46 @@ func (s *OrganizationService) Get(
 	}
 	return output, nil
 }
+
+func (s *OrganizationService) GetList(
+	ctx context.Context, log *slog.Logger, input OrganizationGetListInput,
+) ([]entity.Organization, error) {
+	output, err := s.organizationRepo.GetPagination(ctx, input.Limit, input.Offset, input.Name)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - OrganizationService - GetList: %v", err))
+		return nil, ErrCannotGetOrg
+	}
+	return output, nil
+}
+
+func (s *OrganizationService) Update(
+	ctx context.Context, log *slog.Logger, input OrganizationUpdateInput,
+) (entity.Organization, error) {
+	output, err := s.organizationRepo.Update(
+		ctx, input.Id, entity.Organization{
+			Name:             input.Name,
+			Description:      input.Description,
+			OrganizationType: input.OrganizationType,
+		},
+	)
+	if err != nil {
+		if err == repoerrs.ErrNotFound {
+			return entity.Organization{}, ErrOrgNotFound
+		}
+		log.Error(fmt.Sprintf("Service - OrganizationService - Update: %v", err))
+		return entity.Organization{}, ErrCannotUpdateOrg
+	}
+	return output, nil
+}
+
+func (s *OrganizationService) Delete(ctx context.Context, log *slog.Logger, input OrganizationDeleteInput) error {
+	if err := s.organizationRepo.Delete(ctx, input.Id); err != nil {
+		if err == repoerrs.ErrNotFound {
+			return ErrOrgNotFound
+		}
+		log.Error(fmt.Sprintf("Service - OrganizationService - Delete: %v", err))
+		return ErrCannotDeleteOrg
+	}
+	log.Info(fmt.Sprintf("Service - OrganizationService - organizationRepo.Delete - id: %s", input.Id))
+	return nil
+}
@@ -3,8 +3,12 @@ package service
 import (
 	"context"
 	"log/slog"
+	"time"
 
+	"tender-service/internal/audit"
+	"tender-service/internal/authz"
 	"tender-service/internal/entity"
+	"tender-service/internal/metrics"
 	"tender-service/internal/repo"
 )
 
@@ -22,27 +26,68 @@ type UserGetByUsernameInput struct {
 	Username string
 }
 
+type UserGetListInput struct {
+	Limit  int
+	Offset int
+	Query  string
+}
+
+type UserUpdateInput struct {
+	Id        string
+	FirstName string
+	LastName  string
+}
+
+type UserDeactivateInput struct {
+	Id string
+}
+
 type User interface {
 	Create(ctx context.Context, log *slog.Logger, input UserCreateInput) (string, error)
 	GetById(ctx context.Context, log *slog.Logger, input UserGetByIdInput) (entity.User, error)
 	GetByUsername(ctx context.Context, log *slog.Logger, input UserGetByUsernameInput) (
 		entity.User, error,
 	)
+	GetList(ctx context.Context, log *slog.Logger, input UserGetListInput) ([]entity.User, error)
+	Update(ctx context.Context, log *slog.Logger, input UserUpdateInput) (entity.User, error)
+	Deactivate(ctx context.Context, log *slog.Logger, input UserDeactivateInput) error
 }
 
 type OrganizationCreateInput struct {
 	Name             string
 	Description      string
 	OrganizationType string
+	// CreatorId пользователь, который становится первым ответственным за организацию
+	CreatorId string
 }
 
 type OrganizationGetInput struct {
 	Id string
 }
 
+type OrganizationGetListInput struct {
+	Limit  int
+	Offset int
+	Name   string
+}
+
+type OrganizationUpdateInput struct {
+	Id               string
+	Name             string
+	Description      string
+	OrganizationType string
+}
+
+type OrganizationDeleteInput struct {
+	Id string
+}
+
 type Organization interface {
 	Create(ctx context.Context, log *slog.Logger, input OrganizationCreateInput) (entity.Organization, error)
 	Get(ctx context.Context, log *slog.Logger, input OrganizationGetInput) (entity.Organization, error)
+	GetList(ctx context.Context, log *slog.Logger, input OrganizationGetListInput) ([]entity.Organization, error)
+	Update(ctx context.Context, log *slog.Logger, input OrganizationUpdateInput) (entity.Organization, error)
+	Delete(ctx context.Context, log *slog.Logger, input OrganizationDeleteInput) error
 }
 
 type OrgResponsibleCreateInput struct {
@@ -59,6 +104,17 @@ type OrgResponsibleGetByIdsInput struct {
 	UserId         string
 }
 
+type OrgResponsibleGetByOrganizationInput struct {
+	Limit          int
+	Offset         int
+	OrganizationId string
+}
+
+type OrgResponsibleDeleteInput struct {
+	OrganizationId string
+	UserId         string
+}
+
 type OrgResponsible interface {
 	Create(
 		ctx context.Context, log *slog.Logger, input OrgResponsibleCreateInput,
@@ -69,6 +125,43 @@ type OrgResponsible interface {
 	GetByIds(
 		ctx context.Context, log *slog.Logger, input OrgResponsibleGetByIdsInput,
 	) (entity.OrgResponsible, error)
+	GetByOrganization(
+		ctx context.Context, log *slog.Logger, input OrgResponsibleGetByOrganizationInput,
+	) ([]entity.OrgResponsible, error)
+	Delete(ctx context.Context, log *slog.Logger, input OrgResponsibleDeleteInput) error
+}
+
+type WebhookCreateInput struct {
+	OrganizationId string
+	Url            string
+	EventTypes     []string
+}
+
+type WebhookGetByOrganizationInput struct {
+	OrganizationId string
+}
+
+type WebhookDeleteInput struct {
+	OrganizationId string
+	Id             string
+}
+
+type WebhookGetDeliveriesInput struct {
+	OrganizationId string
+	Id             string
+	Limit          int
+	Offset         int
+}
+
+type Webhook interface {
+	Create(ctx context.Context, log *slog.Logger, input WebhookCreateInput) (entity.Webhook, error)
+	GetByOrganization(
+		ctx context.Context, log *slog.Logger, input WebhookGetByOrganizationInput,
+	) ([]entity.Webhook, error)
+	Delete(ctx context.Context, log *slog.Logger, input WebhookDeleteInput) error
+	GetDeliveries(
+		ctx context.Context, log *slog.Logger, input WebhookGetDeliveriesInput,
+	) ([]entity.WebhookDelivery, error)
 }
 
 type TenderCreateInput struct {
@@ -80,21 +173,40 @@ type TenderCreateInput struct {
 }
 
 type TenderGetByTypeInput struct {
-	Limit       int
-	Offset      int
-	ServiceType []string
+	Limit           int
+	Offset          int
+	After           *entity.Cursor
+	WithTotal       bool
+	Sort            []entity.SortField
+	ServiceType     []string
+	Status          []string
+	OrganizationId  string
+	CreatorUsername string
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	UserId          string
 }
 
 type TenderGetMyInput struct {
 	Limit    int
 	Offset   int
+	After    *entity.Cursor
 	Username string
 }
 
+type TenderSearchInput struct {
+	Limit  int
+	Offset int
+	Query  string
+	UserId string
+}
+
 type TenderEditInput struct {
 	Name        string
 	Description string
 	ServiceType string
+	// ExpectedVersion версия, которую видел клиент; 0 - без проверки
+	ExpectedVersion int
 }
 
 type Tender interface {
@@ -103,17 +215,23 @@ type Tender interface {
 	) (entity.Tender, error)
 	GetByType(
 		ctx context.Context, log *slog.Logger, input TenderGetByTypeInput,
-	) ([]entity.Tender, error)
+	) (entity.Page[entity.Tender], error)
 	GetMy(
 		ctx context.Context, log *slog.Logger, input TenderGetMyInput,
-	) ([]entity.Tender, error)
+	) (entity.Page[entity.Tender], error)
+	Search(ctx context.Context, log *slog.Logger, input TenderSearchInput) ([]entity.Tender, error)
 	GetById(
 		ctx context.Context, log *slog.Logger, id string,
 	) (entity.Tender, error)
-	PutStatus(ctx context.Context, log *slog.Logger, tenderId, status string) (entity.Tender, error)
+	PutStatus(ctx context.Context, log *slog.Logger, tenderId, status string, expectedVersion int) (
+		entity.Tender, error,
+	)
 	EditTender(
 		ctx context.Context, log *slog.Logger, input TenderEditInput, tenderId string,
 	) (entity.Tender, error)
+	Rollback(ctx context.Context, log *slog.Logger, tenderId string, version int) (entity.Tender, error)
+	GetVersions(ctx context.Context, log *slog.Logger, tenderId string) ([]entity.Tender, error)
+	Diff(ctx context.Context, log *slog.Logger, tenderId string, from, to int) ([]entity.FieldDiff, error)
 }
 
 type BidCreateInput struct {
@@ -127,6 +245,7 @@ type BidCreateInput struct {
 type BidGetByTenderIdInput struct {
 	Limit    int
 	Offset   int
+	After    *entity.Cursor
 	TenderId string
 	UserId   string
 }
@@ -134,12 +253,39 @@ type BidGetByTenderIdInput struct {
 type BidGetMyInput struct {
 	Limit  int
 	Offset int
+	After  *entity.Cursor
+	UserId string
+}
+
+type BidSearchInput struct {
+	Limit  int
+	Offset int
+	Query  string
 	UserId string
 }
 
 type BidEditInput struct {
 	Name        string
 	Description string
+	// ExpectedVersion версия, которую видел клиент; 0 - без проверки
+	ExpectedVersion int
+}
+
+type BidSubmitDecisionInput struct {
+	BidId string
+	// User аутентифицированный пользователь, по нему проверяются права
+	User     entity.User
+	Decision string
+}
+
+type BidSubmitDecisionOutput struct {
+	Bid entity.Bid
+	// BidOldVersion версия предложения до решения
+	BidOldVersion int
+	// TenderClosed решение набрало кворум и закрыло тендер, версии тендера до и после закрытия
+	TenderClosed     bool
+	TenderOldVersion int
+	TenderNewVersion int
 }
 
 type Bid interface {
@@ -148,37 +294,99 @@ type Bid interface {
 	) (entity.Bid, error)
 	GetByTenderId(
 		ctx context.Context, log *slog.Logger, input BidGetByTenderIdInput,
-	) ([]entity.Bid, error)
+	) (entity.Page[entity.Bid], error)
 	GetById(
 		ctx context.Context, log *slog.Logger, bidId string,
 	) (entity.Bid, error)
 	GetMy(
 		ctx context.Context, log *slog.Logger, input BidGetMyInput,
-	) ([]entity.Bid, error)
-	PutStatus(ctx context.Context, log *slog.Logger, bidId, status string) (entity.Bid, error)
+	) (entity.Page[entity.Bid], error)
+	Search(ctx context.Context, log *slog.Logger, input BidSearchInput) ([]entity.Bid, error)
+	PutStatus(ctx context.Context, log *slog.Logger, bidId, status string, expectedVersion int) (
+		entity.Bid, error,
+	)
 	EditBid(ctx context.Context, log *slog.Logger, input BidEditInput, bidId string) (
 		entity.Bid, error,
 	)
+	Rollback(ctx context.Context, log *slog.Logger, bidId string, version int) (entity.Bid, error)
+	GetVersions(ctx context.Context, log *slog.Logger, bidId string) ([]entity.Bid, error)
+	Diff(ctx context.Context, log *slog.Logger, bidId string, from, to int) ([]entity.FieldDiff, error)
+	SubmitDecision(
+		ctx context.Context, log *slog.Logger, input BidSubmitDecisionInput,
+	) (BidSubmitDecisionOutput, error)
+}
+
+type BidReviewCreateInput struct {
+	Description string
+	BidId       string
+	// User аутентифицированный пользователь, по нему проверяются права
+	User entity.User
+}
+
+type BidReviewGetByAuthorInput struct {
+	Limit  int
+	Offset int
+	// TenderId тендер, на который автор подал предложение; без такого предложения отзывы не выдаются
+	TenderId       string
+	AuthorId       string
+	OrganizationId string
+}
+
+type BidReview interface {
+	Create(
+		ctx context.Context, log *slog.Logger, input BidReviewCreateInput,
+	) (entity.BidReview, error)
+	GetByAuthor(
+		ctx context.Context, log *slog.Logger, input BidReviewGetByAuthorInput,
+	) ([]entity.BidReview, error)
+}
+
+type AuditGetByEntityInput struct {
+	EntityId string
+	UserId   string
+	Limit    int
+	Offset   int
+}
+
+type Audit interface {
+	GetByEntity(ctx context.Context, log *slog.Logger, input AuditGetByEntityInput) ([]entity.AuditEntry, error)
 }
 
 type Services struct {
 	User           User
 	Organization   Organization
 	OrgResponsible OrgResponsible
+	Webhook        Webhook
 	Tender         Tender
 	Bid            Bid
+	BidReview      BidReview
+	Audit          Audit
+	Authz          *authz.Policy
+	Recorder       *audit.Recorder
 }
 
 type ServicesDependencies struct {
 	Repos *repo.Repositories
+	// SearchLanguage конфигурация стемминга полнотекстового поиска
+	SearchLanguage string
+	Metrics        *metrics.Metrics
 }
 
 func NewServices(dep ServicesDependencies) *Services {
+	policy := authz.New(dep.Repos.OrgResponsible, dep.Repos.Tender)
 	return &Services{
 		User:           NewUserService(dep.Repos.User),
-		Organization:   NewOrganizationService(dep.Repos.Organization),
-		OrgResponsible: NewOrgResponsibleService(dep.Repos.OrgResponsible),
-		Tender:         NewTenderService(dep.Repos.Tender),
-		Bid:            NewBidService(dep.Repos.Bid),
+		Organization:   NewOrganizationService(dep.Repos.Organization, dep.Repos.OrgResponsible, dep.Repos.TxManager),
+		OrgResponsible: NewOrgResponsibleService(dep.Repos.OrgResponsible, dep.Repos.TxManager),
+		Webhook:        NewWebhookService(dep.Repos.Webhook),
+		Tender:         NewTenderService(dep.Repos.Tender, dep.Repos.TxManager, dep.SearchLanguage, dep.Metrics),
+		Bid: NewBidService(
+			dep.Repos.Bid, dep.Repos.BidDecision, dep.Repos.Tender, dep.Repos.TxManager, policy, dep.SearchLanguage,
+			dep.Metrics,
+		),
+		BidReview: NewBidReviewService(dep.Repos.BidReview, dep.Repos.Bid, dep.Repos.Tender, policy),
+		Audit:     NewAuditService(dep.Repos.Audit),
+		Authz:     policy,
+		Recorder:  audit.New(dep.Repos.Audit),
 	}
 }
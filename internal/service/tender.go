@@ -6,16 +6,29 @@ import (
 	"log/slog"
 
 	"tender-service/internal/entity"
+	"tender-service/internal/metrics"
 	"tender-service/internal/repo"
 	"tender-service/internal/repo/repoerrs"
 )
 
+const (
+	tenderStatusPublished = "Published"
+	tenderStatusClosed    = "Closed"
+)
+
 type TenderService struct {
-	tenderRepo repo.Tender
+	tenderRepo     repo.Tender
+	txManager      repo.TxManager
+	searchLanguage string
+	metrics        *metrics.Metrics
 }
 
-func NewTenderService(tenderRepo repo.Tender) *TenderService {
-	return &TenderService{tenderRepo: tenderRepo}
+func NewTenderService(
+	tenderRepo repo.Tender, txManager repo.TxManager, searchLanguage string, metrics *metrics.Metrics,
+) *TenderService {
+	return &TenderService{
+		tenderRepo: tenderRepo, txManager: txManager, searchLanguage: searchLanguage, metrics: metrics,
+	}
 }
 
 func (s *TenderService) Create(
@@ -29,7 +42,13 @@ func (s *TenderService) Create(
 		OrganizationId:  input.OrganizationId,
 		CreatorUsername: input.CreatorUsername,
 	}
-	output, err := s.tenderRepo.Create(ctx, tender)
+	var output entity.Tender
+	// тендер и первый снимок истории сохраняются вместе
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		output, err = s.tenderRepo.Create(ctx, tender)
+		return err
+	})
 	if err != nil {
 		if err == repoerrs.ErrAlreadyExists {
 			return entity.Tender{}, ErrTenderAlreadyExists
@@ -38,27 +57,56 @@ func (s *TenderService) Create(
 		return entity.Tender{}, ErrCannotGetTender
 	}
 	log.Info(fmt.Sprintf("Service - TenderService - tenderRepo.Create - id: %s", output.Id))
+	s.metrics.TenderCreated()
 	return output, nil
 }
 
 func (s *TenderService) GetByType(
 	ctx context.Context, log *slog.Logger, input TenderGetByTypeInput,
-) ([]entity.Tender, error) {
-	output, err := s.tenderRepo.GetByTypePagination(ctx, input.Limit, input.Offset, input.ServiceType)
+) (entity.Page[entity.Tender], error) {
+	output, err := s.tenderRepo.GetByTypePagination(
+		ctx, entity.Pagination{
+			Limit: input.Limit, Offset: input.Offset, After: input.After, Sort: input.Sort, WithTotal: input.WithTotal,
+		},
+		entity.TenderFilter{
+			ServiceType:     input.ServiceType,
+			Status:          input.Status,
+			OrganizationId:  input.OrganizationId,
+			CreatorUsername: input.CreatorUsername,
+			CreatedAfter:    input.CreatedAfter,
+			CreatedBefore:   input.CreatedBefore,
+		},
+		input.UserId,
+	)
 	if err != nil {
 		log.Error(fmt.Sprintf("Service - TenderService - GetByTenderId: %v", err))
-		return nil, ErrCannotGetTender
+		return entity.Page[entity.Tender]{}, ErrCannotGetTender
 	}
 	return output, nil
 }
 
 func (s *TenderService) GetMy(
 	ctx context.Context, log *slog.Logger, input TenderGetMyInput,
-) ([]entity.Tender, error) {
+) (entity.Page[entity.Tender], error) {
 	log.Info(fmt.Sprintf("limit - %d offset - %d", input.Limit, input.Offset))
-	output, err := s.tenderRepo.GetMyPagination(ctx, input.Limit, input.Offset, input.Username)
+	output, err := s.tenderRepo.GetMyPagination(
+		ctx, entity.Pagination{Limit: input.Limit, Offset: input.Offset, After: input.After}, input.Username,
+	)
 	if err != nil {
 		log.Error(fmt.Sprintf("Service - TenderService - GetMy: %v", err))
+		return entity.Page[entity.Tender]{}, ErrCannotGetTender
+	}
+	return output, nil
+}
+
+func (s *TenderService) Search(
+	ctx context.Context, log *slog.Logger, input TenderSearchInput,
+) ([]entity.Tender, error) {
+	output, err := s.tenderRepo.Search(
+		ctx, entity.Pagination{Limit: input.Limit, Offset: input.Offset}, s.searchLanguage, input.Query, input.UserId,
+	)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - TenderService - Search: %v", err))
 		return nil, ErrCannotGetTender
 	}
 	return output, nil
@@ -77,25 +125,44 @@ func (s *TenderService) GetById(
 	return output, nil
 }
 
-func (s *TenderService) PutStatus(ctx context.Context, log *slog.Logger, tenderId, status string) (
-	entity.Tender, error,
-) {
-	err := s.tenderRepo.PutStatus(ctx, tenderId, status)
+// PutStatus при expectedVersion > 0 меняет статус, только если версия тендера не изменилась
+func (s *TenderService) PutStatus(
+	ctx context.Context, log *slog.Logger, tenderId, status string, expectedVersion int,
+) (entity.Tender, error) {
+	var previous, output entity.Tender
+	// статус, версия и снимок истории меняются вместе
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if previous, err = s.tenderRepo.GetById(ctx, tenderId); err != nil {
+			return fmt.Errorf("GetById: %w", err)
+		}
+		if err = s.tenderRepo.PutStatus(ctx, tenderId, status); err != nil {
+			return fmt.Errorf("PutStatus: %w", err)
+		}
+		if err = s.tenderRepo.IncrementVersion(ctx, tenderId, expectedVersion); err != nil {
+			return fmt.Errorf("IncrementVersion: %w", err)
+		}
+
+		if output, err = s.tenderRepo.GetById(ctx, tenderId); err != nil {
+			return fmt.Errorf("GetById: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
+		if conflict := versionConflict(err); conflict != nil {
+			return entity.Tender{}, conflict
+		}
 		log.Error(fmt.Sprintf("Service - TenderService - PutStatus: %v", err))
 		return entity.Tender{}, ErrCannotPutStatus
 	}
-
-	err = s.tenderRepo.IncrementVersion(ctx, tenderId)
-	if err != nil {
-		log.Error(fmt.Sprintf("Service - TenderService - IncrementVersion: %v", err))
-		return entity.Tender{}, ErrCannotIncrement
-	}
-
-	output, err := s.GetById(ctx, log, tenderId)
-	if err != nil {
-		log.Error(fmt.Sprintf("Service - TenderService - GetById: %v", err))
-		return entity.Tender{}, ErrCannotGetTender
+	// повторная установка того же статуса не считается
+	if previous.Status != output.Status {
+		switch output.Status {
+		case tenderStatusPublished:
+			s.metrics.TenderPublished()
+		case tenderStatusClosed:
+			s.metrics.TenderClosed()
+		}
 	}
 	return output, nil
 }
@@ -103,28 +170,96 @@ func (s *TenderService) PutStatus(ctx context.Context, log *slog.Logger, tenderI
 func (s *TenderService) EditTender(
 	ctx context.Context, log *slog.Logger, input TenderEditInput, tenderId string,
 ) (entity.Tender, error) {
-	var err error
 	in := entity.Tender{
 		Name:        input.Name,
 		Description: input.Description,
 		ServiceType: input.ServiceType,
 	}
 
-	if err = s.tenderRepo.EditTender(ctx, in, tenderId); err != nil {
-		log.Error(fmt.Sprintf("Service - TenderService - EditBid: %v", err))
+	var output entity.Tender
+	// правка, версия и снимок истории меняются вместе
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.tenderRepo.EditTender(ctx, in, tenderId); err != nil {
+			return fmt.Errorf("EditTender: %w", err)
+		}
+		if err := s.tenderRepo.IncrementVersion(ctx, tenderId, input.ExpectedVersion); err != nil {
+			return fmt.Errorf("IncrementVersion: %w", err)
+		}
+
+		var err error
+		if output, err = s.tenderRepo.GetById(ctx, tenderId); err != nil {
+			return fmt.Errorf("GetById: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		if conflict := versionConflict(err); conflict != nil {
+			return entity.Tender{}, conflict
+		}
+		log.Error(fmt.Sprintf("Service - TenderService - EditTender: %v", err))
 		return entity.Tender{}, ErrCannotEditTender
 	}
+	return output, nil
+}
+
+func (s *TenderService) Rollback(
+	ctx context.Context, log *slog.Logger, tenderId string, version int,
+) (entity.Tender, error) {
+	var output entity.Tender
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.tenderRepo.Rollback(ctx, tenderId, version); err != nil {
+			return err
+		}
 
-	err = s.tenderRepo.IncrementVersion(ctx, tenderId)
+		var err error
+		if output, err = s.tenderRepo.GetById(ctx, tenderId); err != nil {
+			return fmt.Errorf("GetById: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		log.Error(fmt.Sprintf("Service - TenderService - IncrementVersion: %v", err))
-		return entity.Tender{}, ErrCannotIncrement
+		if err == repoerrs.ErrNotFound {
+			return entity.Tender{}, ErrTenderVersionNotFound
+		}
+		log.Error(fmt.Sprintf("Service - TenderService - Rollback: %v", err))
+		return entity.Tender{}, ErrCannotRollback
 	}
+	return output, nil
+}
 
-	outputNew, err := s.GetById(ctx, log, tenderId)
+func (s *TenderService) GetVersions(
+	ctx context.Context, log *slog.Logger, tenderId string,
+) ([]entity.Tender, error) {
+	output, err := s.tenderRepo.GetVersions(ctx, tenderId)
 	if err != nil {
-		log.Error(fmt.Sprintf("Service - TenderService - EditBid: %v", err))
-		return entity.Tender{}, ErrCannotGetTender
+		log.Error(fmt.Sprintf("Service - TenderService - GetVersions: %v", err))
+		return nil, ErrCannotGetTender
 	}
-	return outputNew, nil
+	return output, nil
+}
+
+func (s *TenderService) Diff(
+	ctx context.Context, log *slog.Logger, tenderId string, from, to int,
+) ([]entity.FieldDiff, error) {
+	var versions [2]entity.Tender
+	for i, version := range []int{from, to} {
+		t, err := s.tenderRepo.GetVersion(ctx, tenderId, version)
+		if err != nil {
+			if err == repoerrs.ErrNotFound {
+				return nil, ErrTenderVersionNotFound
+			}
+			log.Error(fmt.Sprintf("Service - TenderService - Diff: %v", err))
+			return nil, ErrCannotGetTender
+		}
+		versions[i] = t
+	}
+
+	return diffFields(
+		[]fieldPair{
+			{"name", versions[0].Name, versions[1].Name},
+			{"description", versions[0].Description, versions[1].Description},
+			{"serviceType", versions[0].ServiceType, versions[1].ServiceType},
+			{"status", versions[0].Status, versions[1].Status},
+		},
+	), nil
 }
@@ -62,3 +62,41 @@ func (u *UserService) GetByUsername(ctx context.Context, log *slog.Logger, input
 	}
 	return user, nil
 }
+
+func (u *UserService) GetList(ctx context.Context, log *slog.Logger, input UserGetListInput) ([]entity.User, error) {
+	users, err := u.userRepo.GetPagination(ctx, input.Limit, input.Offset, input.Query)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - UserService - GetList: %v", err))
+		return nil, ErrCannotGetUser
+	}
+	return users, nil
+}
+
+func (u *UserService) Update(ctx context.Context, log *slog.Logger, input UserUpdateInput) (entity.User, error) {
+	user, err := u.userRepo.Update(
+		ctx, input.Id, entity.User{
+			FirstName: input.FirstName,
+			LastName:  input.LastName,
+		},
+	)
+	if err != nil {
+		if err == repoerrs.ErrNotFound {
+			return entity.User{}, ErrUserNotFound
+		}
+		log.Error(fmt.Sprintf("Service - UserService - Update: %v", err))
+		return entity.User{}, ErrCannotUpdateUser
+	}
+	return user, nil
+}
+
+func (u *UserService) Deactivate(ctx context.Context, log *slog.Logger, input UserDeactivateInput) error {
+	if err := u.userRepo.Deactivate(ctx, input.Id); err != nil {
+		if err == repoerrs.ErrNotFound {
+			return ErrUserNotFound
+		}
+		log.Error(fmt.Sprintf("Service - UserService - Deactivate: %v", err))
+		return ErrCannotDeactivateUser
+	}
+	log.Info(fmt.Sprintf("Service - UserService - userRepo.Deactivate - id: %s", input.Id))
+	return nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+	"tender-service/internal/repo/repoerrs"
+)
+
+// webhookSecretBytes длина секрета подписи вебхука
+const webhookSecretBytes = 32
+
+type WebhookService struct {
+	webhookRepo repo.Webhook
+}
+
+func NewWebhookService(webhookRepo repo.Webhook) *WebhookService {
+	return &WebhookService{webhookRepo: webhookRepo}
+}
+
+// Create создает подписку со случайным секретом подписи, секрет возвращается только здесь
+func (s *WebhookService) Create(
+	ctx context.Context, log *slog.Logger, input WebhookCreateInput,
+) (entity.Webhook, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		log.Error(fmt.Sprintf("Service - WebhookService - rand.Read: %v", err))
+		return entity.Webhook{}, ErrCannotCreateWebhook
+	}
+
+	output, err := s.webhookRepo.Create(
+		ctx, entity.Webhook{
+			OrganizationId: input.OrganizationId,
+			Url:            input.Url,
+			Secret:         hex.EncodeToString(secret),
+			EventTypes:     input.EventTypes,
+		},
+	)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - WebhookService - Create: %v", err))
+		return entity.Webhook{}, ErrCannotCreateWebhook
+	}
+	log.Info(fmt.Sprintf("Service - WebhookService - webhookRepo.Create - id: %s", output.Id))
+	return output, nil
+}
+
+func (s *WebhookService) GetByOrganization(
+	ctx context.Context, log *slog.Logger, input WebhookGetByOrganizationInput,
+) ([]entity.Webhook, error) {
+	output, err := s.webhookRepo.GetByOrganization(ctx, input.OrganizationId)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - WebhookService - GetByOrganization: %v", err))
+		return nil, ErrCannotGetWebhook
+	}
+	return output, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, log *slog.Logger, input WebhookDeleteInput) error {
+	if err := s.webhookRepo.Delete(ctx, input.OrganizationId, input.Id); err != nil {
+		if err == repoerrs.ErrNotFound {
+			return ErrWebhookNotFound
+		}
+		log.Error(fmt.Sprintf("Service - WebhookService - Delete: %v", err))
+		return ErrCannotDeleteWebhook
+	}
+	log.Info(fmt.Sprintf("Service - WebhookService - webhookRepo.Delete - id: %s", input.Id))
+	return nil
+}
+
+// GetDeliveries журнал доставок подписки организации
+func (s *WebhookService) GetDeliveries(
+	ctx context.Context, log *slog.Logger, input WebhookGetDeliveriesInput,
+) ([]entity.WebhookDelivery, error) {
+	webhook, err := s.webhookRepo.GetById(ctx, input.Id)
+	if err != nil {
+		if err == repoerrs.ErrNotFound {
+			return nil, ErrWebhookNotFound
+		}
+		log.Error(fmt.Sprintf("Service - WebhookService - GetById: %v", err))
+		return nil, ErrCannotGetWebhook
+	}
+	if webhook.OrganizationId != input.OrganizationId {
+		return nil, ErrWebhookNotFound
+	}
+
+	output, err := s.webhookRepo.GetDeliveries(ctx, input.Id, input.Limit, input.Offset)
+	if err != nil {
+		log.Error(fmt.Sprintf("Service - WebhookService - GetDeliveries: %v", err))
+		return nil, ErrCannotGetWebhook
+	}
+	return output, nil
+}
@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"tender-service/internal/entity"
+	"tender-service/internal/repo"
+)
+
+// Body тело запроса вебхука
+type Body struct {
+	Id        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Publisher реализует outbox.EventPublisher: раскладывает событие по подпискам организаций
+// в журнал доставок, отправкой занимается Worker
+type Publisher struct {
+	webhooks repo.Webhook
+}
+
+func NewPublisher(webhooks repo.Webhook) *Publisher {
+	return &Publisher{webhooks: webhooks}
+}
+
+func (p *Publisher) Publish(ctx context.Context, event entity.Event) error {
+	body, err := json.Marshal(Body{
+		Id:        event.Id,
+		Type:      string(event.Type),
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("webhook - Publisher - json.Marshal: %v", err)
+	}
+
+	if _, err = p.webhooks.Enqueue(ctx, event, body); err != nil {
+		return fmt.Errorf("webhook - Publisher - Enqueue: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tender-service/internal/entity"
+)
+
+func newDelivery(url string) entity.WebhookDelivery {
+	return entity.WebhookDelivery{
+		Id:        "delivery",
+		EventType: string(entity.EventBidCreated),
+		Payload:   []byte(`{"id":"bid"}`),
+		Url:       url,
+		Secret:    "secret",
+	}
+}
+
+func TestSenderSignsRequest(t *testing.T) {
+	var (
+		body    []byte
+		headers http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		headers = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	delivery := newDelivery(server.URL)
+	code, err := NewSender(server.Client()).Send(context.Background(), delivery)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if code != http.StatusNoContent {
+		t.Fatalf("Send returned code %d, want %d", code, http.StatusNoContent)
+	}
+
+	if string(body) != string(delivery.Payload) {
+		t.Fatalf("received body %s, want %s", body, delivery.Payload)
+	}
+	if !Verify(delivery.Secret, body, headers.Get(HeaderSignature)) {
+		t.Fatalf("signature %q does not match body", headers.Get(HeaderSignature))
+	}
+	if Verify("other", body, headers.Get(HeaderSignature)) {
+		t.Fatalf("signature matches another secret")
+	}
+	if headers.Get(HeaderEvent) != delivery.EventType || headers.Get(HeaderDelivery) != delivery.Id {
+		t.Fatalf("event and delivery headers are %q and %q", headers.Get(HeaderEvent), headers.Get(HeaderDelivery))
+	}
+}
+
+func TestSenderNon2xx(t *testing.T) {
+	for _, status := range []int{http.StatusMovedPermanently, http.StatusBadRequest, http.StatusInternalServerError} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		code, err := NewSender(server.Client()).Send(context.Background(), newDelivery(server.URL))
+		server.Close()
+		if err == nil {
+			t.Fatalf("status %d: Send returned no error", status)
+		}
+		if code != status {
+			t.Fatalf("status %d: Send returned code %d", status, code)
+		}
+	}
+}
+
+func TestSenderNoResponse(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	code, err := NewSender(nil).Send(context.Background(), newDelivery(url))
+	if err == nil || code != 0 {
+		t.Fatalf("Send returned code %d and error %v, want 0 and error", code, err)
+	}
+}
@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"tender-service/internal/repo"
+)
+
+const (
+	defaultInterval    = time.Second
+	defaultBatchSize   = 20
+	defaultMaxAttempts = 8
+	defaultBackoffBase = 10 * time.Second
+	defaultBackoffMax  = time.Hour
+)
+
+// Worker отправляет доставки, срок которых наступил, и планирует повторы с экспоненциальной задержкой
+type Worker struct {
+	webhooks    repo.Webhook
+	sender      *Sender
+	log         *slog.Logger
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	backoffBase time.Duration
+	backoffMax  time.Duration
+}
+
+type Option func(*Worker)
+
+func Interval(interval time.Duration) Option {
+	return func(w *Worker) {
+		if interval > 0 {
+			w.interval = interval
+		}
+	}
+}
+
+func BatchSize(size int) Option {
+	return func(w *Worker) {
+		if size > 0 {
+			w.batchSize = size
+		}
+	}
+}
+
+// MaxAttempts после стольких неудачных попыток доставка переходит в Failed
+func MaxAttempts(attempts int) Option {
+	return func(w *Worker) {
+		if attempts > 0 {
+			w.maxAttempts = attempts
+		}
+	}
+}
+
+// Backoff задержка перед n-м повтором равна base * 2^(n-1), но не больше max
+func Backoff(base, max time.Duration) Option {
+	return func(w *Worker) {
+		if base > 0 {
+			w.backoffBase = base
+		}
+		if max > 0 {
+			w.backoffMax = max
+		}
+	}
+}
+
+func NewWorker(webhooks repo.Webhook, sender *Sender, log *slog.Logger, opts ...Option) *Worker {
+	w := &Worker{
+		webhooks:    webhooks,
+		sender:      sender,
+		log:         log,
+		interval:    defaultInterval,
+		batchSize:   defaultBatchSize,
+		maxAttempts: defaultMaxAttempts,
+		backoffBase: defaultBackoffBase,
+		backoffMax:  defaultBackoffMax,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Run отправляет доставки до отмены ctx
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.ProcessOnce(ctx); err != nil && ctx.Err() == nil {
+			w.log.Error(fmt.Sprintf("webhook - Worker - Run: %v", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessOnce отправляет одну пачку доставок и возвращает число успешных
+func (w *Worker) ProcessOnce(ctx context.Context) (int, error) {
+	deliveries, err := w.webhooks.ClaimDue(ctx, w.batchSize, w.lease())
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, d := range deliveries {
+		code, sendErr := w.sender.Send(ctx, d)
+		if sendErr == nil {
+			if err = w.webhooks.MarkDelivered(ctx, d.Id, code); err != nil {
+				return delivered, err
+			}
+			delivered++
+			continue
+		}
+
+		var retryAfter time.Duration
+		if attempt := d.Attempts + 1; attempt < w.maxAttempts {
+			retryAfter = w.Backoff(attempt)
+		}
+		w.log.Warn(
+			"webhook - delivery failed",
+			slog.String("delivery", d.Id),
+			slog.Int("attempt", d.Attempts+1),
+			slog.Any("error", sendErr),
+		)
+		if err = w.webhooks.MarkFailed(ctx, d.Id, code, sendErr.Error(), retryAfter); err != nil {
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
+
+// Backoff задержка после attempt-й неудачной попытки
+func (w *Worker) Backoff(attempt int) time.Duration {
+	delay := w.backoffBase
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= w.backoffMax {
+			return w.backoffMax
+		}
+	}
+	return min(delay, w.backoffMax)
+}
+
+// lease на сколько откладывается захваченная доставка: хватает на отправку всей пачки
+func (w *Worker) lease() time.Duration {
+	return time.Duration(w.batchSize+1) * w.sender.timeout()
+}
@@ -0,0 +1,32 @@
+package webhook
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestWorkerBackoff(t *testing.T) {
+	w := NewWorker(
+		nil, NewSender(nil), slog.New(slog.NewTextHandler(io.Discard, nil)), Backoff(10*time.Second, time.Minute),
+	)
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 10 * time.Second},
+		{attempt: 2, want: 20 * time.Second},
+		{attempt: 3, want: 40 * time.Second},
+		{attempt: 4, want: time.Minute},
+		{attempt: 5, want: time.Minute},
+		// без ограничения задержка переполнила бы time.Duration
+		{attempt: 100, want: time.Minute},
+	}
+	for _, tt := range tests {
+		if got := w.Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
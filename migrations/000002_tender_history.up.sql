@@ -0,0 +1,20 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS tender_history
+(
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id       UUID REFERENCES tender (id) ON DELETE CASCADE,
+    name            VARCHAR(100) NOT NULL,
+    description     TEXT,
+    type            service_type,
+    status          tender_status,
+    organization_id UUID,
+    version         INT NOT NULL,
+    created_at      TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, version)
+);
+
+INSERT INTO tender_history (tender_id, name, description, type, status, organization_id, version)
+SELECT id, name, description, type, status, organization_id, version
+FROM tender
+ON CONFLICT DO NOTHING;
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS bid_history
+(
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id      UUID REFERENCES bid (id) ON DELETE CASCADE,
+    name        VARCHAR(100) NOT NULL,
+    description TEXT,
+    status      bid_status,
+    version     INT NOT NULL,
+    created_at  TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, version)
+);
+
+INSERT INTO bid_history (bid_id, name, description, status, version)
+SELECT id, name, description, status, version
+FROM bid
+ON CONFLICT DO NOTHING;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS bid_decision;
+DROP TYPE IF EXISTS bid_decision_type CASCADE;
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+DROP TYPE IF EXISTS bid_decision_type CASCADE;
+CREATE TYPE bid_decision_type AS ENUM (
+    'Approved',
+    'Rejected'
+    );
+
+CREATE TABLE IF NOT EXISTS bid_decision
+(
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id     UUID REFERENCES bid (id) ON DELETE CASCADE,
+    user_id    UUID REFERENCES employee (id) ON DELETE CASCADE,
+    decision   bid_decision_type NOT NULL,
+    created_at TIMESTAMP        DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, user_id)
+);
+COMMIT;
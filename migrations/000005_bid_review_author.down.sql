@@ -0,0 +1,6 @@
+BEGIN;
+DROP INDEX IF EXISTS bid_review_bid_id_idx;
+ALTER TABLE bid_review
+    DROP COLUMN IF EXISTS organization_id,
+    DROP COLUMN IF EXISTS user_id;
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+ALTER TABLE bid_review
+    ADD COLUMN IF NOT EXISTS user_id         UUID REFERENCES employee (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organization (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS bid_review_bid_id_idx ON bid_review (bid_id);
+COMMIT;
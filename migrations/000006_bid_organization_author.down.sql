@@ -0,0 +1,9 @@
+BEGIN;
+ALTER TABLE bid
+    DROP CONSTRAINT IF EXISTS bid_author_ref_check,
+    DROP COLUMN IF EXISTS author_organization_id,
+    DROP COLUMN IF EXISTS author_user_id;
+
+ALTER TABLE bid
+    ADD CONSTRAINT bid_author_id_fkey FOREIGN KEY (author_id) REFERENCES employee (id) ON DELETE CASCADE;
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+ALTER TABLE bid
+    DROP CONSTRAINT IF EXISTS bid_author_id_fkey;
+
+ALTER TABLE bid
+    ADD COLUMN IF NOT EXISTS author_user_id UUID
+        GENERATED ALWAYS AS (CASE WHEN author_type = 'User' THEN author_id END) STORED
+        REFERENCES employee (id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS author_organization_id UUID
+        GENERATED ALWAYS AS (CASE WHEN author_type = 'Organization' THEN author_id END) STORED
+        REFERENCES organization (id) ON DELETE CASCADE;
+
+ALTER TABLE bid
+    ADD CONSTRAINT bid_author_ref_check
+        CHECK (author_user_id IS NOT NULL OR author_organization_id IS NOT NULL);
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_organization_id_fkey,
+    ADD CONSTRAINT tender_organization_id_fkey
+        FOREIGN KEY (organization_id) REFERENCES organization (id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS organization_name_idx;
+
+ALTER TABLE organization
+    DROP COLUMN IF EXISTS deleted_at;
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+ALTER TABLE organization
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS organization_name_idx ON organization (name) WHERE deleted_at IS NULL;
+
+-- организации удаляются мягко, физическое удаление не должно уничтожать тендеры
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_organization_id_fkey,
+    ADD CONSTRAINT tender_organization_id_fkey
+        FOREIGN KEY (organization_id) REFERENCES organization (id) ON DELETE RESTRICT;
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE organization_responsible
+    DROP CONSTRAINT IF EXISTS organization_responsible_user_key;
+COMMIT;
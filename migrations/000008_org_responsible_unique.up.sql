@@ -0,0 +1,28 @@
+BEGIN;
+-- пользователь может быть ответственным только в одной организации.
+-- Существующие дубли миграция не удаляет: от того, какая связь останется, зависят права пользователя,
+-- поэтому выбор делается вручную (README, раздел "Миграция 000008"). При дублях миграция падает
+-- и перечисляет пользователей и организации, за которые они отвечают
+DO
+$$
+    DECLARE
+        conflicts TEXT;
+    BEGIN
+        SELECT string_agg(format('user_id=%s organizations=[%s]', user_id, organizations), '; ' ORDER BY user_id)
+        INTO conflicts
+        FROM (SELECT user_id, string_agg(organization_id::TEXT, ', ' ORDER BY organization_id) AS organizations
+              FROM organization_responsible
+              GROUP BY user_id
+              HAVING count(*) > 1) duplicates;
+
+        IF conflicts IS NOT NULL THEN
+            RAISE EXCEPTION 'organization_responsible has users responsible more than once: %', conflicts
+                USING HINT = 'delete the extra rows manually (README, "Миграция 000008") and rerun migrations';
+        END IF;
+    END
+$$;
+
+-- UNIQUE (user_id) исключает и повтор пары (organization_id, user_id)
+ALTER TABLE organization_responsible
+    ADD CONSTRAINT organization_responsible_user_key UNIQUE (user_id);
+COMMIT;
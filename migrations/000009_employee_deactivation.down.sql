@@ -0,0 +1,9 @@
+BEGIN;
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_creator_username_fkey,
+    ADD CONSTRAINT tender_creator_username_fkey
+        FOREIGN KEY (creator_username) REFERENCES employee (username) ON DELETE CASCADE;
+
+ALTER TABLE employee
+    DROP COLUMN IF EXISTS deactivated_at;
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+ALTER TABLE employee
+    ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP;
+
+-- пользователи деактивируются, а не удаляются: удаление не должно уничтожать их тендеры
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_creator_username_fkey,
+    ADD CONSTRAINT tender_creator_username_fkey
+        FOREIGN KEY (creator_username) REFERENCES employee (username) ON DELETE RESTRICT;
+COMMIT;
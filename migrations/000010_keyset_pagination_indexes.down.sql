@@ -0,0 +1,5 @@
+BEGIN;
+DROP INDEX IF EXISTS bid_tender_name_id_idx;
+DROP INDEX IF EXISTS bid_name_id_idx;
+DROP INDEX IF EXISTS tender_name_id_idx;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+CREATE INDEX IF NOT EXISTS tender_name_id_idx ON tender (name, id);
+CREATE INDEX IF NOT EXISTS bid_name_id_idx ON bid (name, id);
+CREATE INDEX IF NOT EXISTS bid_tender_name_id_idx ON bid (tender_id, name, id);
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+DROP INDEX IF EXISTS bid_search_en_idx;
+DROP INDEX IF EXISTS bid_search_ru_idx;
+DROP INDEX IF EXISTS tender_search_en_idx;
+DROP INDEX IF EXISTS tender_search_ru_idx;
+
+ALTER TABLE bid
+    DROP COLUMN IF EXISTS search_en,
+    DROP COLUMN IF EXISTS search_ru;
+
+ALTER TABLE tender
+    DROP COLUMN IF EXISTS search_en,
+    DROP COLUMN IF EXISTS search_ru;
+COMMIT;
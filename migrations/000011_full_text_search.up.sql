@@ -0,0 +1,31 @@
+BEGIN;
+-- по колонке на каждую конфигурацию стемминга, нужная выбирается через search.language в config.yaml
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS search_ru TSVECTOR
+        GENERATED ALWAYS AS (
+            setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+            setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+            ) STORED,
+    ADD COLUMN IF NOT EXISTS search_en TSVECTOR
+        GENERATED ALWAYS AS (
+            setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+            setweight(to_tsvector('english', coalesce(description, '')), 'B')
+            ) STORED;
+
+ALTER TABLE bid
+    ADD COLUMN IF NOT EXISTS search_ru TSVECTOR
+        GENERATED ALWAYS AS (
+            setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+            setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+            ) STORED,
+    ADD COLUMN IF NOT EXISTS search_en TSVECTOR
+        GENERATED ALWAYS AS (
+            setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+            setweight(to_tsvector('english', coalesce(description, '')), 'B')
+            ) STORED;
+
+CREATE INDEX IF NOT EXISTS tender_search_ru_idx ON tender USING GIN (search_ru);
+CREATE INDEX IF NOT EXISTS tender_search_en_idx ON tender USING GIN (search_en);
+CREATE INDEX IF NOT EXISTS bid_search_ru_idx ON bid USING GIN (search_ru);
+CREATE INDEX IF NOT EXISTS bid_search_en_idx ON bid USING GIN (search_en);
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id           UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_type   VARCHAR(100) NOT NULL,
+    aggregate_id UUID         NOT NULL,
+    payload      JSONB        NOT NULL,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP,
+    attempts     INT          NOT NULL DEFAULT 0,
+    last_error   TEXT
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (created_at) WHERE published_at IS NULL;
+COMMIT;
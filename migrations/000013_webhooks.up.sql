@@ -0,0 +1,34 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS webhook
+(
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID          NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    url             VARCHAR(1000) NOT NULL,
+    secret          VARCHAR(100)  NOT NULL,
+    event_types     VARCHAR(100)[] NOT NULL,
+    created_at      TIMESTAMP     NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS webhook_organization_idx ON webhook (organization_id);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery
+(
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id      UUID         NOT NULL REFERENCES webhook (id) ON DELETE CASCADE,
+    event_id        UUID         NOT NULL,
+    event_type      VARCHAR(100) NOT NULL,
+    payload         JSONB        NOT NULL,
+    status          VARCHAR(20)  NOT NULL DEFAULT 'Pending',
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    response_code   INT,
+    last_error      TEXT,
+    created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at    TIMESTAMP,
+    -- повторная доставка события из outbox не создает дубликатов
+    CONSTRAINT webhook_delivery_event_key UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_idx ON webhook_delivery (next_attempt_at) WHERE status = 'Pending';
+CREATE INDEX IF NOT EXISTS webhook_delivery_webhook_idx ON webhook_delivery (webhook_id, created_at DESC);
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+-- журнал не ссылается на сущности внешними ключами, чтобы переживать их удаление
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id        UUID         NOT NULL,
+    actor_username  VARCHAR(50)  NOT NULL,
+    action          VARCHAR(30)  NOT NULL,
+    entity_type     VARCHAR(30)  NOT NULL,
+    entity_id       UUID         NOT NULL,
+    organization_id UUID,
+    old_version     INT,
+    new_version     INT,
+    request_id      VARCHAR(100) NOT NULL DEFAULT '',
+    created_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity_id, created_at DESC);
+COMMIT;
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+	"tender-service/internal/entity"
+	"tender-service/pkg/response"
+	"tender-service/pkg/token"
+)
+
+const bearerPrefix = "Bearer "
+
+type ctxKey int
+
+const userCtxKey ctxKey = iota
+
+// UserResolver возвращает пользователя по subject из токена
+type UserResolver func(ctx context.Context, subject string) (entity.User, error)
+
+// WithUser кладет пользователя в контекст запроса
+func WithUser(ctx context.Context, user entity.User) context.Context {
+	return context.WithValue(ctx, userCtxKey, user)
+}
+
+// UserFromContext достает пользователя, положенного в контекст middleware Auth
+func UserFromContext(ctx context.Context) (entity.User, bool) {
+	user, ok := ctx.Value(userCtxKey).(entity.User)
+	return user, ok
+}
+
+func Auth(log *slog.Logger, tokens *token.Manager, resolve UserResolver) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log = log.With(
+			slog.String("component", "middleware/auth"),
+		)
+
+		log.Info("auth middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if !strings.HasPrefix(header, bearerPrefix) {
+				unauthorized(w, r, "Missing bearer token")
+				return
+			}
+
+			subject, err := tokens.Parse(strings.TrimPrefix(header, bearerPrefix))
+			if err != nil {
+				log.Info("invalid token", slog.Any("error", err))
+				unauthorized(w, r, "Invalid token")
+				return
+			}
+
+			user, err := resolve(r.Context(), subject)
+			if err != nil {
+				log.Info("cannot resolve token subject", slog.String("subject", subject), slog.Any("error", err))
+				unauthorized(w, r, "User not found")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, message string) {
+	w.WriteHeader(http.StatusUnauthorized)
+	render.JSON(w, r, response.MakeResponse(message))
+}
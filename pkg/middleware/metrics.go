@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// unmatchedRoute метка запросов, не попавших ни в один маршрут, чтобы пути не раздували число серий
+const unmatchedRoute = "unmatched"
+
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, duration time.Duration)
+}
+
+// Metrics учитывает количество и время обработки запросов по шаблону маршрута chi
+func Metrics(observer RequestObserver) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			t1 := time.Now()
+
+			next.ServeHTTP(ww, r)
+
+			// шаблон маршрута известен только после того, как chi выполнил маршрутизацию
+			route := unmatchedRoute
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			observer.ObserveRequest(r.Method, route, status, time.Since(t1))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
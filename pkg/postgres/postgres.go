@@ -31,6 +31,7 @@ type PgxPool interface {
 		int64, error,
 	)
 	Ping(ctx context.Context) error
+	Stat() *pgxpool.Stat
 }
 
 type Database struct {
@@ -72,8 +73,7 @@ func New(ctx context.Context, connString string, opts ...Option) (*Database, err
 		db.connAttempts--
 	}
 	if err != nil {
-		fmt.Errorf("database - New - pgxpool.NewWithConfig: %w", err)
-		return nil, err
+		return nil, fmt.Errorf("database - New - pgxpool.NewWithConfig: %w", err)
 	}
 	return db, nil
 }
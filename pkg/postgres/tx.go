@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	defaultTxAttempts = 3
+	defaultTxBackoff  = 10 * time.Millisecond
+)
+
+type txCtxKey struct{}
+
+// Querier общая часть пула и транзакции, через которую работают репозитории
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// TxFromContext транзакция, открытая TxManager.Do
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txCtxKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// Conn транзакция из контекста, если она есть, иначе пул
+func (db *Database) Conn(ctx context.Context) Querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return db.Cluster
+}
+
+// Begin начинает транзакцию; внутри транзакции из контекста - вложенную (SAVEPOINT)
+func (db *Database) Begin(ctx context.Context) (pgx.Tx, error) {
+	return db.Conn(ctx).Begin(ctx)
+}
+
+// TxManager выполняет несколько вызовов репозиториев одной транзакцией
+type TxManager struct {
+	db       *Database
+	options  pgx.TxOptions
+	attempts int
+	backoff  time.Duration
+}
+
+type TxOption func(*TxManager)
+
+func TxIsoLevel(level pgx.TxIsoLevel) TxOption {
+	return func(m *TxManager) {
+		m.options.IsoLevel = level
+	}
+}
+
+// TxAttempts сколько раз выполнить функцию, если транзакция не прошла сериализацию
+func TxAttempts(attempts int) TxOption {
+	return func(m *TxManager) {
+		if attempts > 0 {
+			m.attempts = attempts
+		}
+	}
+}
+
+func NewTxManager(db *Database, opts ...TxOption) *TxManager {
+	m := &TxManager{
+		db:       db,
+		options:  pgx.TxOptions{IsoLevel: pgx.Serializable},
+		attempts: defaultTxAttempts,
+		backoff:  defaultTxBackoff,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Do выполняет fn в транзакции, доступной репозиториям через контекст fn.
+// Если в ctx уже есть транзакция, fn присоединяется к ней. При ошибке сериализации
+// или взаимоблокировке транзакция повторяется целиком, поэтому fn не должна иметь внешних побочных эффектов.
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	var err error
+	for attempt := 1; attempt <= m.attempts; attempt++ {
+		if err = m.run(ctx, fn); err == nil || !IsRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * m.backoff):
+		}
+	}
+	return err
+}
+
+func (m *TxManager) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := m.db.Cluster.BeginTx(ctx, m.options)
+	if err != nil {
+		return fmt.Errorf("TxManager - Do - BeginTx: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err = fn(context.WithValue(ctx, txCtxKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("TxManager - Do - tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// IsRetryable транзакцию можно повторить: конфликт сериализации или взаимоблокировка
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgerrcode.SerializationFailure || pgErr.Code == pgerrcode.DeadlockDetected
+}
@@ -0,0 +1,60 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const defaultTTL = 24 * time.Hour
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Manager выпускает и проверяет HMAC-подписанные JWT, subject токена - id пользователя
+type Manager struct {
+	signingKey []byte
+	ttl        time.Duration
+}
+
+func New(signingKey string, ttl time.Duration) *Manager {
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return &Manager{signingKey: []byte(signingKey), ttl: ttl}
+}
+
+func (m *Manager) Issue(subject string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   subject,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.signingKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("token - Issue - SignedString: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+func (m *Manager) Parse(tokenString string) (string, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(
+		tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+			return m.signingKey, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return "", fmt.Errorf("%w: empty subject", ErrInvalidToken)
+	}
+	return claims.Subject, nil
+}